@@ -0,0 +1,391 @@
+package print
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// SafeTag is the struct tag marking fields an AllowMasker
+// prints in the clear, e.g. `print:"safe"`, it also sets the
+// level read by a LevelMasker, e.g. `print:"internal"`. Values
+// are comma separated, e.g. `print:"safe,internal"`
+const SafeTag = "print"
+
+const (
+	safeValue = "safe"
+	// allowRule names the values masked by an AllowMasker in reports
+	allowRule = "allowlist"
+)
+
+// AllowMasker is a Masker for output leaving a trust boundary.
+// It inverts denylist masking: only values marked safe print in
+// the clear, everything else is masked. Values are safe when
+//
+//   - their struct field is tagged `print:"safe"`
+//   - their path matches an allowed path, e.g. $.items[*].id
+//   - their key matches an allowed key rule
+//   - their type is registered with AllowTypes
+//
+// Safe values keep their whole subtree in the clear. The shape
+// of the output, keys and array lengths, is preserved. Values
+// printed through String or MarshalJSON are masked as a whole
+type AllowMasker struct {
+	paths    []*regexp.Regexp
+	keys     []KeyRule
+	types    map[reflect.Type]bool
+	strategy MaskStrategy
+}
+
+// NewAllowMasker creates an AllowMasker, masked
+// values are replaced with "****"
+func NewAllowMasker() *AllowMasker {
+	return &AllowMasker{
+		types:    make(map[reflect.Type]bool),
+		strategy: defaultStrategy,
+	}
+}
+
+// AllowPaths marks the values at paths as safe. A * matches
+// any key and [*] any index, e.g. $.items[*].id
+func (m *AllowMasker) AllowPaths(patterns ...string) *AllowMasker {
+	for _, pattern := range patterns {
+		m.paths = append(m.paths, compilePathPattern(pattern))
+	}
+	return m
+}
+
+// AllowKeys marks the values stored under matching keys as safe
+func (m *AllowMasker) AllowKeys(rules ...KeyRule) *AllowMasker {
+	m.keys = append(m.keys, rules...)
+	return m
+}
+
+// AllowTypes marks the values with the same type as the
+// given examples as safe, e.g. AllowTypes(time.Time{})
+func (m *AllowMasker) AllowTypes(examples ...any) *AllowMasker {
+	for _, example := range examples {
+		m.types[reflect.TypeOf(example)] = true
+	}
+	return m
+}
+
+// WithStrategy sets the strategy used to mask values
+func (m *AllowMasker) WithStrategy(s MaskStrategy) *AllowMasker {
+	m.strategy = s
+	return m
+}
+
+// Mask returns a copy of target where only safe values are kept
+func (m *AllowMasker) Mask(target any) (any, error) {
+	return m.maskReport(target, nil)
+}
+
+func (m *AllowMasker) maskReport(target any, hit func(path, rule string)) (any, error) {
+	if target == nil {
+		return nil, nil
+	}
+
+	out, err := newAllowWalker(m, hit).walk(rootPath, reflect.ValueOf(target))
+	if err != nil {
+		return nil, err
+	}
+	return out.Interface(), nil
+}
+
+// maskTree masks every leaf of the normalized tree that is not
+// safe. Struct tags and types are lost in the tree, so data is
+// walked again to find the safe paths. Values exposed through
+// String or MarshalJSON are replaced as a whole
+func (m *AllowMasker) maskTree(data, tree any, masked map[string]bool, hit func(path, rule string)) (any, error) {
+	w := newAllowWalker(m, nil)
+	if data != nil {
+		if _, err := w.walk(rootPath, reflect.ValueOf(data)); err != nil {
+			return nil, err
+		}
+	}
+	return w.maskTree(rootPath, tree, masked, hit)
+}
+
+func (m *AllowMasker) safePath(path string) bool {
+	for _, re := range m.paths {
+		if re.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *AllowMasker) safeKey(key string) bool {
+	for _, rule := range m.keys {
+		if rule.Matches(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowWalker masks a value with an AllowMasker, recording the
+// paths found safe and the masked output of opaque values
+type allowWalker struct {
+	masker *AllowMasker
+	hit    func(path, rule string)
+	safe   map[string]bool
+	opaque map[string]string
+}
+
+func newAllowWalker(m *AllowMasker, hit func(path, rule string)) *allowWalker {
+	return &allowWalker{
+		masker: m,
+		hit:    hit,
+		safe:   make(map[string]bool),
+		opaque: make(map[string]string),
+	}
+}
+
+func (w *allowWalker) record(path string) {
+	if w.hit != nil {
+		w.hit(path, allowRule)
+	}
+}
+
+func (w *allowWalker) walk(path string, val reflect.Value) (reflect.Value, error) {
+	if !val.IsValid() {
+		return val, nil
+	}
+
+	if w.masker.safePath(path) || w.masker.types[val.Type()] {
+		w.safe[path] = true
+		return val, nil
+	}
+
+	if isOpaque(val) {
+		// String and MarshalJSON can print unexported fields,
+		// their output is masked as a single value
+		if err := w.maskOpaque(path, val); err != nil {
+			return val, err
+		}
+		w.record(path)
+		return maskValue(val, w.masker.strategy)
+	}
+
+	switch val.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if val.IsNil() {
+			return val, nil
+		}
+		if val.Kind() == reflect.Interface && isScalar(val.Elem().Kind()) && !w.masker.types[val.Elem().Type()] {
+			// masked as the interface so 42 prints as "****"
+			break
+		}
+		elem, err := w.walk(path, val.Elem())
+		if err != nil {
+			return val, err
+		}
+		if val.Kind() == reflect.Interface {
+			return wrapValue(val.Type(), elem), nil
+		}
+		out := reflect.New(val.Type().Elem())
+		out.Elem().Set(elem)
+		return out, nil
+
+	case reflect.Map:
+		if val.IsNil() {
+			return val, nil
+		}
+		out := reflect.MakeMapWithSize(val.Type(), val.Len())
+		for _, key := range val.MapKeys() {
+			name := fmt.Sprintf("%v", key.Interface())
+			item := val.MapIndex(key)
+			if !w.masker.safeKey(name) {
+				var err error
+				if item, err = w.walk(keyPath(path, name), item); err != nil {
+					return val, err
+				}
+			}
+			out.SetMapIndex(key, item)
+		}
+		return out, nil
+
+	case reflect.Slice, reflect.Array:
+		if val.Kind() == reflect.Slice && val.IsNil() {
+			return val, nil
+		}
+		out := reflect.New(val.Type()).Elem()
+		if val.Kind() == reflect.Slice {
+			out = reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+		}
+		for i := range val.Len() {
+			item, err := w.walk(indexPath(path, i), val.Index(i))
+			if err != nil {
+				return val, err
+			}
+			out.Index(i).Set(item)
+		}
+		return out, nil
+
+	case reflect.Struct:
+		if exportedFields(val.Type()) == 0 {
+			break
+		}
+
+		// unexported fields are left zero
+		out := reflect.New(val.Type()).Elem()
+
+		t := val.Type()
+		for i := range t.NumField() {
+			field := t.Field(i)
+			name, _, ok := jsonFieldName(field)
+			if !ok {
+				continue
+			}
+
+			if slices.Contains(printTags(field), safeValue) || w.masker.safeKey(name) || w.masker.safeKey(field.Name) {
+				w.safe[keyPath(path, name)] = true
+				out.Field(i).Set(val.Field(i))
+				continue
+			}
+
+			item, err := w.walk(keyPath(path, name), val.Field(i))
+			if err != nil {
+				return val, err
+			}
+			out.Field(i).Set(item)
+		}
+		return out, nil
+	}
+
+	w.record(path)
+	return maskValue(val, w.masker.strategy)
+}
+
+// maskOpaque masks the JSON output of val as a single value
+func (w *allowWalker) maskOpaque(path string, val reflect.Value) error {
+	tree, err := jsonTree(val.Interface())
+	if err != nil {
+		return err
+	}
+
+	s, ok := tree.(string)
+	if !ok {
+		b, err := json.Marshal(tree)
+		if err != nil {
+			return err
+		}
+		s = string(b)
+	}
+
+	if w.opaque[path], err = w.masker.strategy.MaskString(s); err != nil {
+		return fmt.Errorf("error masking value: %w", err)
+	}
+	return nil
+}
+
+// maskTree masks the leaves of a normalized tree that are not
+// safe, values at masked paths were masked by the first pass
+func (w *allowWalker) maskTree(path string, v any, masked map[string]bool, hit func(path, rule string)) (any, error) {
+	if value, ok := w.opaque[path]; ok {
+		return value, nil
+	}
+
+	if masked[path] || w.safe[path] || w.masker.safePath(path) {
+		return v, nil
+	}
+
+	switch value := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(value))
+		for key, item := range value {
+			if w.masker.safeKey(key) {
+				out[key] = item
+				continue
+			}
+			masked, err := w.maskTree(keyPath(path, key), item, masked, hit)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = masked
+		}
+		return out, nil
+
+	case []any:
+		out := make([]any, len(value))
+		for i, item := range value {
+			masked, err := w.maskTree(indexPath(path, i), item, masked, hit)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = masked
+		}
+		return out, nil
+
+	case nil:
+		return nil, nil
+
+	default:
+		out, err := w.masker.strategy.MaskString(fmt.Sprintf("%v", value))
+		if err != nil {
+			return nil, fmt.Errorf("error masking value: %w", err)
+		}
+		if hit != nil {
+			hit(path, allowRule)
+		}
+		return out, nil
+	}
+}
+
+// isOpaque reports if val is printed through String or MarshalJSON
+func isOpaque(val reflect.Value) bool {
+	if (val.Kind() == reflect.Interface || val.Kind() == reflect.Ptr) && val.IsNil() {
+		return false
+	}
+	if !val.CanInterface() {
+		return false
+	}
+
+	switch val.Interface().(type) {
+	case json.Marshaler, fmt.Stringer:
+		return true
+	}
+	return false
+}
+
+// printTags returns the comma separated values of the print tag
+func printTags(f reflect.StructField) []string {
+	tag := f.Tag.Get(SafeTag)
+	if tag == empty {
+		return nil
+	}
+
+	values := strings.Split(tag, ",")
+	for i, value := range values {
+		values[i] = strings.TrimSpace(value)
+	}
+	return values
+}
+
+func exportedFields(t reflect.Type) int {
+	n := 0
+	for i := range t.NumField() {
+		if t.Field(i).IsExported() {
+			n++
+		}
+	}
+	return n
+}
+
+// compilePathPattern converts a path pattern into a regular
+// expression matching the path and its descendants
+func compilePathPattern(pattern string) *regexp.Regexp {
+	if !strings.HasPrefix(pattern, rootPath) {
+		pattern = rootPath + "." + pattern
+	}
+
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.ReplaceAll(expr, `\[\*\]`, `\[\d+\]`)
+	expr = strings.ReplaceAll(expr, `\*`, `[^.\[\]]+`)
+	return regexp.MustCompile(`^` + expr + `(?:$|[.\[])`)
+}
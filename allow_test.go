@@ -0,0 +1,207 @@
+package print
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+type allowItem struct {
+	ID    int    `json:"id"`
+	Label string `json:"label"`
+}
+
+type allowEvent struct {
+	ID        string         `json:"id" print:"safe"`
+	Type      string         `json:"type" print:"safe"`
+	Email     string         `json:"email"`
+	Amount    int            `json:"amount"`
+	CreatedAt time.Time      `json:"created_at"`
+	Items     []allowItem    `json:"items"`
+	Extra     map[string]any `json:"extra"`
+}
+
+func TestAllowMasker(t *testing.T) {
+	original := PrintMasker
+	defer func() { PrintMasker = original }()
+
+	PrintMasker = NewAllowMasker().
+		AllowPaths("$.items[*].id", "extra.trace").
+		AllowKeys(RegexKey(regexp.MustCompile(`^request_`))).
+		AllowTypes(time.Time{})
+
+	event := allowEvent{
+		ID:        "evt_1",
+		Type:      "charge",
+		Email:     "john@example.com",
+		Amount:    4200,
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Items:     []allowItem{{ID: 7, Label: "pen"}},
+		Extra: map[string]any{
+			"trace":      map[string]any{"span": "abc"},
+			"request_id": "req_9",
+			"note":       "call me",
+			"count":      3,
+		},
+	}
+
+	got, report, err := SecureJSONWithReport(event)
+	if err != nil {
+		t.Fatalf("SecureJSONWithReport() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`"id": "evt_1"`,
+		`"type": "charge"`,
+		`"email": "****"`,
+		`"amount": 0`,
+		`"created_at": "2024-01-02T03:04:05Z"`,
+		`"id": 7`,
+		`"label": "****"`,
+		`"span": "abc"`,
+		`"request_id": "req_9"`,
+		`"note": "****"`,
+		`"count": "****"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("SecureJSON() missing %s:\n%s", want, got)
+		}
+	}
+
+	for _, leaked := range []string{"john@example.com", "4200", "pen", "call me"} {
+		if strings.Contains(got, leaked) {
+			t.Errorf("SecureJSON() leaked %q:\n%s", leaked, got)
+		}
+	}
+
+	entry, ok := report.Entry("$.email")
+	if !ok || entry.Rule != allowRule || entry.OriginalLength != 16 {
+		t.Errorf("Entry($.email) = %+v, %v", entry, ok)
+	}
+
+	if report.Masked("$.id") {
+		t.Errorf("Masked($.id) = true, want false")
+	}
+}
+
+type allowLogin struct {
+	User string `json:"user"`
+	pass string
+}
+
+func (l allowLogin) String() string {
+	return l.User + " pass=" + l.pass
+}
+
+type allowToken struct {
+	ID     string `json:"id" print:"safe"`
+	secret string
+}
+
+func (t allowToken) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"id": t.ID, "secret": t.secret})
+}
+
+type allowSession struct {
+	ID    string     `json:"id" print:"safe"`
+	Login allowLogin `json:"login"`
+	Token allowToken `json:"token"`
+	Extra any        `json:"extra"`
+	key   string
+}
+
+func TestAllowMaskerOpaqueValues(t *testing.T) {
+	original := PrintMasker
+	defer func() { PrintMasker = original }()
+
+	PrintMasker = NewAllowMasker()
+
+	session := allowSession{
+		ID:    "sess_1",
+		Login: allowLogin{User: "john", pass: "hunter2"},
+		Token: allowToken{ID: "tok_1", secret: "s3cr3t"},
+		Extra: &allowLogin{User: "jane", pass: "letmein"},
+		key:   "k3y",
+	}
+
+	got, err := SecureJSON(session)
+	if err != nil {
+		t.Fatalf("SecureJSON() error = %v", err)
+	}
+
+	for _, want := range []string{
+		`"id": "sess_1"`,
+		`"login": "****"`,
+		`"token": "****"`,
+		`"extra": "****"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("SecureJSON() missing %s:\n%s", want, got)
+		}
+	}
+
+	for _, leaked := range []string{"john", "hunter2", "tok_1", "s3cr3t", "jane", "letmein"} {
+		if strings.Contains(got, leaked) {
+			t.Errorf("SecureJSON() leaked %q:\n%s", leaked, got)
+		}
+	}
+
+	masked, err := PrintMasker.Mask(session)
+	if err != nil {
+		t.Fatalf("Mask() error = %v", err)
+	}
+
+	out := masked.(allowSession)
+	if out.key != empty || out.Login.pass != empty || out.Token.secret != empty {
+		t.Errorf("Mask() kept unexported fields: %+v", out)
+	}
+}
+
+func TestAllowMaskerTreeLeaves(t *testing.T) {
+	original := PrintMasker
+	defer func() { PrintMasker = original }()
+
+	PrintMasker = NewAllowMasker().AllowPaths("$.items[*].id")
+
+	data := map[string]any{
+		"items":  []any{map[string]any{"id": 1, "name": "pen"}},
+		"active": true,
+		"note":   nil,
+	}
+
+	got, err := SecureCompactJSON(data)
+	if err != nil {
+		t.Fatalf("SecureCompactJSON() error = %v", err)
+	}
+
+	want := `{"active":"****","items":[{"id":1,"name":"****"}],"note":null}`
+	if got != want {
+		t.Errorf("SecureCompactJSON() = %s, want %s", got, want)
+	}
+}
+
+func TestCompilePathPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"$.user.name", "$.user.name", true},
+		{"user.name", "$.user.name", true},
+		{"$.user", "$.user.name", true},
+		{"$.user", "$.username", false},
+		{"$.items[*].id", "$.items[12].id", true},
+		{"$.items[*].id", "$.items[0].label", false},
+		{"$.*.id", "$.order.id", true},
+		{"$.*.id", "$.order.item.id", false},
+		{`$["x-trace"]`, `$["x-trace"]`, true},
+	}
+
+	for _, tt := range tests {
+		if got := compilePathPattern(tt.pattern).MatchString(tt.path); got != tt.want {
+			t.Errorf("pattern %s matching %s = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
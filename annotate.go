@@ -0,0 +1,251 @@
+package print
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const commentPrefix = " // "
+
+// maskedNote replaces the annotation of masked values so
+// their Go type and length are not revealed
+const maskedNote = "masked"
+
+// AnnotatedJSON prints data as JSONC using the default Printer
+// configuration, each value is annotated with its Go type
+func AnnotatedJSON(data any) (string, error) {
+	return NewPrinter().AnnotatedJSON(data)
+}
+
+// MaybeAnnotatedJSON will return annotated JSON, in case of
+// an error it will return the message: error printing
+func MaybeAnnotatedJSON(data any) string {
+	return NewPrinter().MaybeAnnotatedJSON(data)
+}
+
+// SecureAnnotatedJSON will mask sensitive data and print it as
+// JSONC using the default Printer configuration
+func SecureAnnotatedJSON(data any) (string, error) {
+	return NewPrinter().SecureAnnotatedJSON(data)
+}
+
+// MaybeSecureAnnotatedJSON will return masked annotated JSON, in
+// case of an error it will return the message: error printing
+func MaybeSecureAnnotatedJSON(data any) string {
+	return NewPrinter().MaybeSecureAnnotatedJSON(data)
+}
+
+// AnnotatedJSON prints data as JSON with comments. Each value is
+// annotated with its Go type and collections with their length:
+//
+//	"items": [ // []models.Item len=3
+func (p *Printer) AnnotatedJSON(data any) (string, error) {
+	tree, err := jsonTree(data)
+	if err != nil {
+		return empty, err
+	}
+	return p.renderAnnotated(data, tree, nil)
+}
+
+// MaybeAnnotatedJSON will return annotated JSON, in case of
+// an error it will return the message: error printing
+func (p *Printer) MaybeAnnotatedJSON(data any) string {
+	out, err := p.AnnotatedJSON(data)
+	if err != nil {
+		return fmt.Sprintf("error printing: %s", err)
+	}
+	return out
+}
+
+// SecureAnnotatedJSON will mask sensitive data and print
+// it as JSON with type and size comments. Masked values
+// are annotated as masked, hiding their type and length
+func (p *Printer) SecureAnnotatedJSON(data any) (string, error) {
+	// rules can mask a collection item by item, the
+	// collection itself is annotated as masked too
+	hits := make(map[string]bool)
+	original, tree, err := maskTreeWith(p.secureMasker(), data, func(path, _ string) {
+		hits[path] = true
+	})
+	if err != nil {
+		return empty, err
+	}
+
+	masked := maskedPaths(original, tree)
+	for path := range hits {
+		masked[path] = true
+	}
+	return p.renderAnnotated(data, tree, masked)
+}
+
+// MaybeSecureAnnotatedJSON will return masked annotated JSON, in
+// case of an error it will return the message: error printing
+func (p *Printer) MaybeSecureAnnotatedJSON(data any) string {
+	out, err := p.SecureAnnotatedJSON(data)
+	if err != nil {
+		return fmt.Sprintf("error printing: %s", err)
+	}
+	return out
+}
+
+// WithMaxItems truncates arrays and objects to n items in
+// annotated output, the comment records the truncation
+func WithMaxItems(n int) Option {
+	return func(p *Printer) {
+		p.maxItems = n
+	}
+}
+
+func (p *Printer) renderAnnotated(data, tree any, masked map[string]bool) (string, error) {
+	notes := make(map[string]string)
+	annotateTypes(rootPath, reflect.ValueOf(data), notes)
+
+	for path := range masked {
+		notes[path] = maskedNote
+	}
+
+	if p.maxItems > 0 {
+		tree = truncateTree(rootPath, tree, p.maxItems, notes)
+	}
+
+	tokens := annotateTokens(jsonTokens(tree, masked), notes)
+	return p.highlightTokens(os.Stdout, tokens)
+}
+
+// annotateTypes walks data the same way safeToJSON does and
+// records the Go type of each value by path
+func annotateTypes(path string, val reflect.Value, notes map[string]string) {
+	if !val.IsValid() {
+		return
+	}
+
+	if val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return
+		}
+		val = val.Elem()
+	}
+
+	note := val.Type().String()
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			notes[path] = note
+			return
+		}
+		val = val.Elem()
+	}
+
+	// values converted to strings are not walked
+	if val.CanInterface() {
+		switch val.Interface().(type) {
+		case json.Number, json.Marshaler, fmt.Stringer, time.Time:
+			notes[path] = note
+			return
+		}
+	}
+
+	switch val.Kind() {
+	case reflect.Array:
+		note += fmt.Sprintf(" len=%d", val.Len())
+	case reflect.Slice, reflect.Map:
+		if !val.IsNil() {
+			note += fmt.Sprintf(" len=%d", val.Len())
+		}
+	}
+	notes[path] = note
+
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := range val.Len() {
+			annotateTypes(indexPath(path, i), val.Index(i), notes)
+		}
+	case reflect.Map:
+		for _, key := range val.MapKeys() {
+			annotateTypes(keyPath(path, fmt.Sprintf("%v", key.Interface())), val.MapIndex(key), notes)
+		}
+	case reflect.Struct:
+		t := val.Type()
+		for i := range t.NumField() {
+			if name, _, ok := jsonFieldName(t.Field(i)); ok {
+				annotateTypes(keyPath(path, name), val.Field(i), notes)
+			}
+		}
+	}
+}
+
+// truncateTree keeps the first n items of arrays and objects
+func truncateTree(path string, v any, n int, notes map[string]string) any {
+	switch value := v.(type) {
+	case map[string]any:
+		keys := sortedKeys(value)
+		if len(keys) > n {
+			notes[path] += fmt.Sprintf(" truncated=%d", len(keys)-n)
+			keys = keys[:n]
+		}
+		out := make(map[string]any, len(keys))
+		for _, key := range keys {
+			out[key] = truncateTree(keyPath(path, key), value[key], n, notes)
+		}
+		return out
+	case []any:
+		if len(value) > n {
+			notes[path] += fmt.Sprintf(" truncated=%d", len(value)-n)
+			value = value[:n]
+		}
+		out := make([]any, len(value))
+		for i, item := range value {
+			out[i] = truncateTree(indexPath(path, i), item, n, notes)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// annotateTokens inserts comments at the end of the line that
+// opens a collection or holds a scalar value
+func annotateTokens(tokens []Token, notes map[string]string) []Token {
+	out := make([]Token, 0, len(tokens)+len(notes))
+
+	var pending *Token
+	for _, token := range tokens {
+		if token.Kind == TokenWhitespace && strings.Contains(token.Value, "\n") && pending != nil {
+			out = append(out, *pending)
+			pending = nil
+		}
+
+		out = append(out, token)
+
+		note, ok := notes[token.Path]
+		if !ok || !annotates(token) {
+			continue
+		}
+
+		pending = &Token{
+			Kind:  TokenComment,
+			Value: commentPrefix + strings.TrimSpace(note),
+			Path:  token.Path,
+		}
+	}
+
+	return out
+}
+
+// annotates reports if token is the one a value
+// comment should follow
+func annotates(token Token) bool {
+	switch token.Kind {
+	case TokenString, TokenNumber, TokenBool, TokenNull:
+		return true
+	case TokenPunctuation:
+		switch token.Value {
+		case "{", "[", "{}", "[]":
+			return true
+		}
+	}
+	return false
+}
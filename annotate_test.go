@@ -0,0 +1,155 @@
+package print
+
+import (
+	"strings"
+	"testing"
+)
+
+type annotatedItem struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type annotatedOrder struct {
+	Items    []annotatedItem `json:"items"`
+	Password string          `json:"password"`
+	Tags     map[string]int  `json:"tags"`
+	Note     *string         `json:"note"`
+}
+
+func TestAnnotatedJSON(t *testing.T) {
+	order := annotatedOrder{
+		Items:    []annotatedItem{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}, {ID: 3, Name: "c"}},
+		Password: "secret123",
+		Tags:     map[string]int{},
+	}
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		secure   bool
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "types and sizes",
+			contains: []string{
+				"{ // print.annotatedOrder\n",
+				`"items": [ // []print.annotatedItem len=3` + "\n",
+				"\t\t{ // print.annotatedItem\n",
+				`"id": 1, // int` + "\n",
+				`"name": "c" // string` + "\n",
+				`"note": null, // *string` + "\n",
+				`"password": "secret123", // string` + "\n",
+				`"tags": {} // map[string]int len=0` + "\n",
+			},
+		},
+		{
+			name: "truncated",
+			opts: []Option{WithMaxItems(2)},
+			contains: []string{
+				`"items": [ // []print.annotatedItem len=3 truncated=1`,
+				"{ // print.annotatedOrder truncated=2\n",
+				`"items"`,
+				`"note"`,
+			},
+			excludes: []string{`"name": "c"`, `"password"`},
+		},
+		{
+			name:     "secure",
+			secure:   true,
+			contains: []string{`"password": "****", // masked`},
+			excludes: []string{"secret123"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPrinter(append(tt.opts, WithColorMode(ColorNever))...)
+
+			annotate := p.AnnotatedJSON
+			if tt.secure {
+				annotate = p.SecureAnnotatedJSON
+			}
+
+			got, err := annotate(order)
+			if err != nil {
+				t.Fatalf("AnnotatedJSON() error = %v", err)
+			}
+
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("AnnotatedJSON() missing %q\ngot:\n%s", want, got)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("AnnotatedJSON() should not contain %q\ngot:\n%s", unwanted, got)
+				}
+			}
+		})
+	}
+}
+
+func TestAnnotatedJSONHighlight(t *testing.T) {
+	p := NewPrinter(
+		WithColorMode(ColorAlways),
+		WithHighlighter(NewANSIHighlighter(Color16)),
+	)
+
+	got, err := p.AnnotatedJSON(map[string]any{"ok": true})
+	if err != nil {
+		t.Fatalf("AnnotatedJSON() error = %v", err)
+	}
+
+	want := Palette16.Comment + " // bool" + ansiReset
+	if !strings.Contains(got, want) {
+		t.Errorf("AnnotatedJSON() missing coloured comment %q\ngot: %q", want, got)
+	}
+}
+
+func TestSecureAnnotatedJSON(t *testing.T) {
+	original := PrintMasker
+	defer func() { PrintMasker = original }()
+
+	PrintMasker = ChainMasker(PrintMasker, NewKeyMasker(FoldKey("pin"), FoldKey("backup_codes")))
+
+	data := map[string]any{
+		"name":         "john",
+		"pin":          1234,
+		"backup_codes": [2]string{"a1b2", "c3d4"},
+	}
+
+	t.Setenv("NO_COLOR", "1")
+
+	got, err := SecureAnnotatedJSON(data)
+	if err != nil {
+		t.Fatalf("SecureAnnotatedJSON() error = %v", err)
+	}
+
+	contains := []string{
+		`"backup_codes": [ // masked`,
+		`"name": "john", // string`,
+		`"pin": "****" // masked`,
+	}
+	for _, want := range contains {
+		if !strings.Contains(got, want) {
+			t.Errorf("SecureAnnotatedJSON() missing %q\ngot:\n%s", want, got)
+		}
+	}
+
+	// the type and length of masked values must not leak
+	for _, unwanted := range []string{"1234", "a1b2", "// int", "[2]string", "len=2"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("SecureAnnotatedJSON() should not contain %q\ngot:\n%s", unwanted, got)
+		}
+	}
+
+	if got := MaybeSecureAnnotatedJSON(data); !strings.Contains(got, `"pin": "****" // masked`) {
+		t.Errorf("MaybeSecureAnnotatedJSON() = %s", got)
+	}
+
+	if got := MaybeAnnotatedJSON(data); !strings.Contains(got, `"pin": 1234 // int`) {
+		t.Errorf("MaybeAnnotatedJSON() = %s", got)
+	}
+}
@@ -0,0 +1,21 @@
+package print
+
+// ANSI escape sequences used when we colour output ourselves
+const (
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiDim     = "\x1b[2m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiYellow  = "\x1b[33m"
+	ansiBlue    = "\x1b[34m"
+	ansiMagenta = "\x1b[35m"
+	ansiCyan    = "\x1b[36m"
+)
+
+func colorize(s, color string, enabled bool) string {
+	if !enabled || color == empty {
+		return s
+	}
+	return color + s + ansiReset
+}
@@ -0,0 +1,70 @@
+// Command goprint-unmask reveals the values encrypted by
+// print.MaskEncrypt in a secure dump.
+//
+// Usage:
+//
+//	GOPRINT_ENCRYPTION_KEY=<base64 key> goprint-unmask dump.json
+//	goprint-unmask -key-file key.txt < dump.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	print "github.com/goliatone/go-print"
+)
+
+func main() {
+	keyFile := flag.String("key-file", "", "file holding the base64 encoded key, defaults to $"+print.EncryptionKeyEnv)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [-key-file file] [dump]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(*keyFile, flag.Arg(0)); err != nil {
+		fmt.Fprintf(os.Stderr, "goprint-unmask: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(keyFile, name string) error {
+	encoded := os.Getenv(print.EncryptionKeyEnv)
+	if keyFile != "" {
+		b, err := os.ReadFile(keyFile)
+		if err != nil {
+			return err
+		}
+		encoded = string(b)
+	}
+
+	key, err := print.DecodeKey(encoded)
+	if err != nil {
+		return err
+	}
+
+	input := io.Reader(os.Stdin)
+	if name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		input = f
+	}
+
+	dump, err := io.ReadAll(input)
+	if err != nil {
+		return err
+	}
+
+	out, err := print.Unmask(string(dump), key)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(os.Stdout, out)
+	return err
+}
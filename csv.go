@@ -0,0 +1,126 @@
+package print
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"os"
+)
+
+type csvConfig struct {
+	delimiter rune
+}
+
+// CSVOption configures the output of the CSV writers
+type CSVOption func(*csvConfig)
+
+// WithDelimiter sets the field delimiter, use '\t' for TSV
+func WithDelimiter(delimiter rune) CSVOption {
+	return func(c *csvConfig) {
+		c.delimiter = delimiter
+	}
+}
+
+// WriteCSV will write a slice of structs or maps as CSV.
+// Nested fields are flattened to dot-path column names,
+// e.g. address.city
+func WriteCSV(w io.Writer, data any, opts ...CSVOption) error {
+	columns, rows, err := recordRows(data)
+	if err != nil {
+		return err
+	}
+	return writeCSV(w, columns, rows, opts)
+}
+
+// WriteSecureCSV will mask sensitive data before
+// writing it as CSV
+func WriteSecureCSV(w io.Writer, data any, opts ...CSVOption) error {
+	columns, rows, err := secureRecordRows(data)
+	if err != nil {
+		return err
+	}
+	return writeCSV(w, columns, rows, opts)
+}
+
+func writeCSV(w io.Writer, columns []string, rows []map[string]any, opts []CSVOption) error {
+	cfg := &csvConfig{delimiter: ','}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	header, records := flattenRecords(columns, rows)
+
+	writer := csv.NewWriter(w)
+	writer.Comma = cfg.delimiter
+
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		line := make([]string, len(header))
+		for i, column := range header {
+			line[i] = cellString(record[column])
+		}
+		if err := writer.Write(line); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// SaveCSVFile will create a new file with CSV content
+func SaveCSVFile(name string, data any, opts ...CSVOption) error {
+	buffer := new(bytes.Buffer)
+	if err := WriteCSV(buffer, data, opts...); err != nil {
+		return err
+	}
+	return os.WriteFile(name, buffer.Bytes(), 0644)
+}
+
+// SaveSecureCSVFile will create a new file with masked CSV content
+func SaveSecureCSVFile(name string, data any, opts ...CSVOption) error {
+	buffer := new(bytes.Buffer)
+	if err := WriteSecureCSV(buffer, data, opts...); err != nil {
+		return err
+	}
+	return os.WriteFile(name, buffer.Bytes(), 0644)
+}
+
+// flattenRecords flattens each row and returns the dot-path
+// columns grouped by the top level column they belong to
+func flattenRecords(columns []string, rows []map[string]any) ([]string, []map[string]any) {
+	seen := make(map[string]bool)
+	groups := make(map[string][]string, len(columns))
+	records := make([]map[string]any, len(rows))
+
+	for i, row := range rows {
+		records[i] = make(map[string]any)
+		for _, column := range columns {
+			value, ok := row[column]
+			if !ok {
+				continue
+			}
+			for _, field := range defaultFlattener.flatten(column, value, nil) {
+				records[i][field.key] = field.value
+				if !seen[field.key] {
+					seen[field.key] = true
+					groups[column] = append(groups[column], field.key)
+				}
+			}
+		}
+	}
+
+	header := make([]string, 0, len(seen))
+	for _, column := range columns {
+		if len(groups[column]) == 0 {
+			header = append(header, column)
+			continue
+		}
+		header = append(header, groups[column]...)
+	}
+
+	return header, records
+}
@@ -0,0 +1,96 @@
+package print
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type csvAddress struct {
+	City    string `json:"city"`
+	Country string `json:"country"`
+}
+
+type csvUser struct {
+	Name     string     `json:"name"`
+	Password string     `json:"password"`
+	Address  csvAddress `json:"address"`
+	Tags     []string   `json:"tags,omitempty"`
+}
+
+func TestWriteCSV(t *testing.T) {
+	users := []csvUser{
+		{Name: "alice", Password: "secret", Address: csvAddress{City: "Berlin", Country: "DE"}, Tags: []string{"a", "b"}},
+		{Name: "bob, jr", Password: "hunter2", Address: csvAddress{City: "Paris"}},
+	}
+
+	tests := []struct {
+		name  string
+		write func(*bytes.Buffer) error
+		want  string
+	}{
+		{
+			name: "flattens nested fields",
+			write: func(b *bytes.Buffer) error {
+				return WriteCSV(b, users)
+			},
+			want: `name,password,address.city,address.country,tags.0,tags.1
+alice,secret,Berlin,DE,a,b
+"bob, jr",hunter2,Paris,,,
+`,
+		},
+		{
+			name: "masks before export",
+			write: func(b *bytes.Buffer) error {
+				return WriteSecureCSV(b, users[1:])
+			},
+			want: `name,password,address.city,address.country,tags
+"bob, jr",****,Paris,,
+`,
+		},
+		{
+			name: "tsv delimiter",
+			write: func(b *bytes.Buffer) error {
+				return WriteCSV(b, []map[string]any{{"a": 1, "b": true}}, WithDelimiter('\t'))
+			},
+			want: "a\tb\n1\ttrue\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+			if err := tt.write(buffer); err != nil {
+				t.Fatalf("write error = %v", err)
+			}
+			if got := buffer.String(); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSaveSecureCSVFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	filename := filepath.Join(tmpDir, "users.csv")
+
+	users := []csvUser{{Name: "alice", Password: "secret"}}
+	if err := SaveSecureCSVFile(filename, users); err != nil {
+		t.Fatalf("SaveSecureCSVFile() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+
+	want := "name,password,address.city,address.country,tags\nalice,****,,,\n"
+	if string(content) != want {
+		t.Errorf("file content = %q, want %q", content, want)
+	}
+
+	if err := SaveCSVFile(filename, "not a slice"); err == nil {
+		t.Error("SaveCSVFile() expected error for non slice input")
+	}
+}
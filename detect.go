@@ -0,0 +1,362 @@
+package print
+
+import (
+	"net"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Detector finds secrets inside string values, e.g. a bearer
+// token in an error message
+type Detector interface {
+	// Name identifies the detector, e.g. jwt
+	Name() string
+	// Detect returns the [start, end) byte offsets of the
+	// secrets found in s
+	Detect(s string) [][2]int
+}
+
+// regexDetector reports the matches of a regular expression,
+// or of its first group when it has one
+type regexDetector struct {
+	name     string
+	regex    *regexp.Regexp
+	validate func(match string) bool
+}
+
+// NewRegexDetector creates a Detector reporting the matches of re.
+// When re has a capture group only the first group is reported,
+// e.g. the token in `Bearer (\S+)`
+func NewRegexDetector(name string, re *regexp.Regexp) Detector {
+	return &regexDetector{name: name, regex: re}
+}
+
+func (d *regexDetector) Name() string {
+	return d.name
+}
+
+func (d *regexDetector) Detect(s string) [][2]int {
+	var spans [][2]int
+	for _, match := range d.regex.FindAllStringSubmatchIndex(s, -1) {
+		start, end := match[0], match[1]
+		if len(match) > 2 && match[2] >= 0 {
+			start, end = match[2], match[3]
+		}
+		if d.validate != nil && !d.validate(s[start:end]) {
+			continue
+		}
+		spans = append(spans, [2]int{start, end})
+	}
+	return spans
+}
+
+// Built in detectors
+var (
+	JWTDetector = NewRegexDetector("jwt",
+		regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]+`))
+	BearerDetector = NewRegexDetector("bearer",
+		regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9\-._~+/]+=*)`))
+	AWSKeyDetector = NewRegexDetector("aws_access_key",
+		regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16}\b`))
+	GitHubTokenDetector = NewRegexDetector("github_token",
+		regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})`))
+	SlackTokenDetector = NewRegexDetector("slack_token",
+		regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`))
+	PrivateKeyDetector = NewRegexDetector("private_key",
+		regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY-----[\s\S]*?-----END (?:[A-Z0-9]+ )*PRIVATE KEY-----`))
+	CreditCardDetector Detector = &regexDetector{
+		name:     "credit_card",
+		regex:    regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`),
+		validate: luhn,
+	}
+	EmailDetector = NewRegexDetector("email",
+		regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`))
+	// URLCredentialsDetector finds the password of URLs and
+	// DSNs, e.g. postgres://app:****@db/app
+	URLCredentialsDetector = NewRegexDetector("url_credentials",
+		regexp.MustCompile(`\b[A-Za-z][A-Za-z0-9+.-]*://[^:/?#@\s]*:([^@/?#\s]+)@`))
+)
+
+// Compliance detectors, used by the presets
+var (
+	// TrackDataDetector finds magnetic stripe track 1 and 2 data
+	TrackDataDetector = NewRegexDetector("track_data",
+		regexp.MustCompile(`%B\d{13,19}\^[^^?]{2,26}\^\d{7}[^?]*\?|;\d{13,19}=\d{7}[^?]*\?`))
+	// PhoneDetector finds E.164 and formatted phone numbers, e.g.
+	// +14155550123, +44 20 7946 0958, (415) 555-0123 or 415-555-0123.
+	// Digit runs separated by spaces alone are not phone numbers
+	PhoneDetector = NewRegexDetector("phone",
+		regexp.MustCompile(`\+[1-9]\d{7,14}\b|\+\d{1,3}[ .-]?(?:\(\d{1,4}\)|\d{1,4})(?:[ .-]\d{2,4}){2,4}\b|\(\d{2,4}\) ?\d{3,4}[ .-]\d{3,4}\b|\b\d{2,4}-\d{3,4}-\d{3,4}\b|\b\d{2,4}\.\d{3,4}\.\d{3,4}\b`))
+	// IPAddressDetector finds IPv4 and IPv6 addresses
+	IPAddressDetector Detector = ipDetector{}
+	// NationalIDDetector finds US social security numbers
+	// and UK national insurance numbers
+	NationalIDDetector Detector = &regexDetector{
+		name:     "national_id",
+		regex:    regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b|\b[A-CEGHJ-PR-TW-Z]{2} ?\d{2} ?\d{2} ?\d{2} ?[A-D]\b`),
+		validate: validNationalID,
+	}
+)
+
+// DefaultDetectors lists the built in detectors
+var DefaultDetectors = []Detector{
+	JWTDetector,
+	BearerDetector,
+	AWSKeyDetector,
+	GitHubTokenDetector,
+	SlackTokenDetector,
+	PrivateKeyDetector,
+	CreditCardDetector,
+	URLCredentialsDetector,
+	EmailDetector,
+}
+
+// builtinDetectors lists every detector selectable by name
+func builtinDetectors() []Detector {
+	return append(DefaultDetectors[:len(DefaultDetectors):len(DefaultDetectors)],
+		TrackDataDetector, PhoneDetector, IPAddressDetector, NationalIDDetector)
+}
+
+var (
+	ipv4Candidate = regexp.MustCompile(`\b\d{1,3}(?:\.\d{1,3}){3}\b`)
+	ipv6Candidate = regexp.MustCompile(`(?:[0-9A-Fa-f]{0,4}:){2,7}(?:\d{1,3}(?:\.\d{1,3}){3}|[0-9A-Fa-f]{0,4})`)
+)
+
+// ipDetector reports the IPv4 and IPv6 addresses that stand
+// alone, C++ scopes like std::vector are not addresses
+type ipDetector struct{}
+
+func (ipDetector) Name() string {
+	return "ip_address"
+}
+
+func (ipDetector) Detect(s string) [][2]int {
+	var spans [][2]int
+	for _, re := range []*regexp.Regexp{ipv4Candidate, ipv6Candidate} {
+		for _, match := range re.FindAllStringIndex(s, -1) {
+			start, end := match[0], match[1]
+			if standalone(s, start, end) && validIP(s[start:end]) {
+				spans = append(spans, [2]int{start, end})
+			}
+		}
+	}
+	// IPv4 tails of IPv6 addresses are found twice
+	return mergeSpans(spans)
+}
+
+// validIP accepts IPv4 addresses and IPv6 addresses
+// with at least two groups, bare :: spans are rejected
+func validIP(match string) bool {
+	if net.ParseIP(match) == nil {
+		return false
+	}
+	if !strings.Contains(match, ":") {
+		return true
+	}
+
+	groups := 0
+	for _, group := range strings.FieldsFunc(match, func(r rune) bool { return r == ':' }) {
+		if group != empty {
+			groups++
+		}
+	}
+	return groups >= 2
+}
+
+// standalone reports if s[start:end] is not part of
+// a longer word or version number
+func standalone(s string, start, end int) bool {
+	if start > 0 && (isWordByte(s[start-1]) || s[start-1] == '.') {
+		return false
+	}
+	if end < len(s) && isWordByte(s[end]) {
+		return false
+	}
+	return end+1 >= len(s) || s[end] != '.' || !isWordByte(s[end+1])
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// validNationalID rejects social security numbers
+// with an area, group or serial that is never issued
+func validNationalID(match string) bool {
+	if len(match) != 11 || match[3] != '-' {
+		return true
+	}
+	area, group, serial := match[:3], match[4:6], match[7:]
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+// luhn validates the check digit of a card number,
+// spaces and dashes are ignored
+func luhn(number string) bool {
+	sum, digits := 0, 0
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c == ' ' || c == '-' {
+			continue
+		}
+		if c < '0' || c > '9' {
+			return false
+		}
+
+		n := int(c - '0')
+		if digits%2 == 1 {
+			n *= 2
+			if n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+		digits++
+	}
+	return digits >= 13 && digits <= 19 && sum%10 == 0
+}
+
+// DetectorMasker is a Masker that scans every string value with
+// its detectors and masks only the matching spans:
+//
+//	"request failed: Bearer ****"
+//
+// Add it to the secure pipeline using ChainMasker:
+//
+//	print.PrintMasker = print.ChainMasker(print.PrintMasker, print.NewDetectorMasker(print.DefaultDetectors...))
+//
+// Tokens produced by MaskEncrypt are never masked again,
+// so they can still be revealed with Unmask
+type DetectorMasker struct {
+	detectors []Detector
+	strategy  MaskStrategy
+	except    []KeyRule
+}
+
+// NewDetectorMasker creates a DetectorMasker with detectors,
+// matches are replaced with "****"
+func NewDetectorMasker(detectors ...Detector) *DetectorMasker {
+	return &DetectorMasker{detectors: detectors, strategy: defaultStrategy}
+}
+
+// WithStrategy sets the strategy used to mask matches
+func (m *DetectorMasker) WithStrategy(s MaskStrategy) *DetectorMasker {
+	m.strategy = s
+	return m
+}
+
+// Except leaves the values matching rules untouched
+func (m *DetectorMasker) Except(rules ...KeyRule) *DetectorMasker {
+	m.except = append(m.except, rules...)
+	return m
+}
+
+// Mask returns a copy of target with the secrets found
+// in string values masked
+func (m *DetectorMasker) Mask(target any) (any, error) {
+	return m.maskReport(target, nil)
+}
+
+func (m *DetectorMasker) maskReport(target any, hit func(path, rule string)) (any, error) {
+	if target == nil {
+		return nil, nil
+	}
+
+	w := maskWalker{text: m.maskText, skip: exceptRules(m.except), hit: hit}
+	out, err := w.walk(rootPath, reflect.ValueOf(target))
+	if err != nil {
+		return nil, err
+	}
+	return out.Interface(), nil
+}
+
+// MaskString masks the secrets found in s
+func (m *DetectorMasker) MaskString(s string) (string, error) {
+	out, _, err := m.maskText(s)
+	return out, err
+}
+
+// maskText masks the secrets found in s, it returns the
+// rule naming the detectors that matched, e.g. detector:jwt
+func (m *DetectorMasker) maskText(s string) (string, string, error) {
+	spans, names := m.detect(s)
+	if len(spans) == 0 {
+		return s, empty, nil
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, span := range spans {
+		masked, err := m.strategy.MaskString(s[span[0]:span[1]])
+		if err != nil {
+			return s, empty, err
+		}
+		b.WriteString(s[last:span[0]])
+		b.WriteString(masked)
+		last = span[1]
+	}
+	b.WriteString(s[last:])
+	return b.String(), "detector:" + strings.Join(names, ","), nil
+}
+
+// detect returns the sorted spans found by all detectors,
+// overlapping spans are merged, and the detector names.
+// Spans overlapping encrypted tokens are dropped
+func (m *DetectorMasker) detect(s string) ([][2]int, []string) {
+	encrypted := encryptedToken.FindAllStringIndex(s, -1)
+
+	var spans [][2]int
+	var names []string
+	for _, d := range m.detectors {
+		found := withoutSpans(d.Detect(s), encrypted)
+		if len(found) > 0 {
+			spans = append(spans, found...)
+			names = append(names, d.Name())
+		}
+	}
+	return mergeSpans(spans), names
+}
+
+// withoutSpans drops the spans overlapping any of skip
+func withoutSpans(spans [][2]int, skip [][]int) [][2]int {
+	if len(skip) == 0 {
+		return spans
+	}
+
+	out := spans[:0:0]
+	for _, span := range spans {
+		overlaps := false
+		for _, s := range skip {
+			if span[0] < s[1] && s[0] < span[1] {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			out = append(out, span)
+		}
+	}
+	return out
+}
+
+func mergeSpans(spans [][2]int) [][2]int {
+	if len(spans) < 2 {
+		return spans
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i][0] < spans[j][0]
+	})
+
+	merged := spans[:1]
+	for _, span := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if span[0] <= last[1] {
+			last[1] = max(last[1], span[1])
+			continue
+		}
+		merged = append(merged, span)
+	}
+	return merged
+}
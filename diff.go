@@ -0,0 +1,290 @@
+package print
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ChangeType describes how a value changed between two values
+type ChangeType string
+
+const (
+	ChangeAdded   ChangeType = "added"
+	ChangeRemoved ChangeType = "removed"
+	ChangeUpdated ChangeType = "changed"
+)
+
+const defaultDiffContext = 3
+
+// Change is a single path level difference
+type Change struct {
+	Path string     `json:"path"`
+	Type ChangeType `json:"type"`
+	Old  any        `json:"old,omitempty"`
+	New  any        `json:"new,omitempty"`
+}
+
+type diffConfig struct {
+	sliceKey string
+	color    bool
+	context  int
+}
+
+// DiffOption configures Diff
+type DiffOption func(*diffConfig)
+
+// WithSliceKey matches slice elements by the given key field
+// instead of by index, e.g. WithSliceKey("id"). Slices whose
+// elements do not all have a unique key are matched by index
+func WithSliceKey(key string) DiffOption {
+	return func(c *diffConfig) {
+		c.sliceKey = key
+	}
+}
+
+// WithDiffColor enables or disables ANSI colours in the unified
+// diff. By default colours are enabled when stdout is a terminal,
+// honouring NO_COLOR, FORCE_COLOR and TERM=dumb
+func WithDiffColor(enabled bool) DiffOption {
+	return func(c *diffConfig) {
+		c.color = enabled
+	}
+}
+
+// WithDiffContext sets the number of context lines
+// in the unified diff
+func WithDiffContext(lines int) DiffOption {
+	return func(c *diffConfig) {
+		c.context = lines
+	}
+}
+
+func newDiffConfig(opts ...DiffOption) *diffConfig {
+	cfg := &diffConfig{
+		color:   colorEnabled(os.Stdout, ColorAuto),
+		context: defaultDiffContext,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// DiffResult holds the differences between two values
+type DiffResult struct {
+	Changes []Change
+	before  string
+	after   string
+	config  *diffConfig
+}
+
+// Diff compares two values after masking and normalizing them.
+// It returns the list of paths that were added, removed
+// or changed between a and b
+func Diff(a, b any, opts ...DiffOption) (*DiffResult, error) {
+	cfg := newDiffConfig(opts...)
+
+	before, beforeTree, err := diffSide(a)
+	if err != nil {
+		return nil, err
+	}
+
+	after, afterTree, err := diffSide(b)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &differ{config: cfg}
+	d.compare(rootPath, beforeTree, afterTree)
+
+	return &DiffResult{
+		Changes: d.changes,
+		before:  before,
+		after:   after,
+		config:  cfg,
+	}, nil
+}
+
+func diffSide(data any) (string, any, error) {
+	_, tree, err := maskTree(data)
+	if err != nil {
+		return empty, nil, err
+	}
+
+	out, err := PrettyJSON(tree)
+	if err != nil {
+		return empty, nil, fmt.Errorf("error printing data: %w", err)
+	}
+
+	return out, tree, nil
+}
+
+// Equal reports whether both values are the same
+func (r *DiffResult) Equal() bool {
+	return len(r.Changes) == 0
+}
+
+// String returns the path level list of changes
+func (r *DiffResult) String() string {
+	var b strings.Builder
+	for _, change := range r.Changes {
+		switch change.Type {
+		case ChangeAdded:
+			b.WriteString(colorize(fmt.Sprintf("+ %s: %s", change.Path, diffValue(change.New)), ansiGreen, r.config.color))
+		case ChangeRemoved:
+			b.WriteString(colorize(fmt.Sprintf("- %s: %s", change.Path, diffValue(change.Old)), ansiRed, r.config.color))
+		default:
+			b.WriteString(colorize(fmt.Sprintf("~ %s: %s -> %s", change.Path, diffValue(change.Old), diffValue(change.New)), ansiYellow, r.config.color))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Unified returns a unified diff of the pretty printed values
+func (r *DiffResult) Unified() string {
+	if r.Equal() {
+		return empty
+	}
+	return unifiedDiff(r.before, r.after, r.config.context, r.config.color)
+}
+
+func diffValue(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return unsupportedMessage
+	}
+	return string(b)
+}
+
+type differ struct {
+	config  *diffConfig
+	changes []Change
+}
+
+func (d *differ) add(path string, kind ChangeType, old, new any) {
+	d.changes = append(d.changes, Change{Path: path, Type: kind, Old: old, New: new})
+}
+
+func (d *differ) compare(path string, a, b any) {
+	switch av := a.(type) {
+	case map[string]any:
+		if bv, ok := b.(map[string]any); ok {
+			d.compareMaps(path, av, bv)
+			return
+		}
+	case []any:
+		if bv, ok := b.([]any); ok {
+			d.compareSlices(path, av, bv)
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		d.add(path, ChangeUpdated, a, b)
+	}
+}
+
+func (d *differ) compareMaps(path string, a, b map[string]any) {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		av, inA := a[key]
+		bv, inB := b[key]
+		switch {
+		case !inA:
+			d.add(keyPath(path, key), ChangeAdded, nil, bv)
+		case !inB:
+			d.add(keyPath(path, key), ChangeRemoved, av, nil)
+		default:
+			d.compare(keyPath(path, key), av, bv)
+		}
+	}
+}
+
+func (d *differ) compareSlices(path string, a, b []any) {
+	if d.config.sliceKey != empty {
+		keysA, okA := sliceKeys(a, d.config.sliceKey)
+		keysB, okB := sliceKeys(b, d.config.sliceKey)
+		if okA && okB {
+			d.compareKeyed(path, a, b, keysA, keysB)
+			return
+		}
+	}
+
+	for i := range max(len(a), len(b)) {
+		switch {
+		case i >= len(a):
+			d.add(indexPath(path, i), ChangeAdded, nil, b[i])
+		case i >= len(b):
+			d.add(indexPath(path, i), ChangeRemoved, a[i], nil)
+		default:
+			d.compare(indexPath(path, i), a[i], b[i])
+		}
+	}
+}
+
+func (d *differ) compareKeyed(path string, a, b []any, keysA, keysB []string) {
+	index := make(map[string]int, len(keysB))
+	for i, key := range keysB {
+		index[key] = i
+	}
+
+	matched := make(map[string]bool, len(keysA))
+	for i, key := range keysA {
+		elemPath := fmt.Sprintf("%s[%s=%s]", path, d.config.sliceKey, key)
+		matched[key] = true
+		if j, ok := index[key]; ok {
+			d.compare(elemPath, a[i], b[j])
+		} else {
+			d.add(elemPath, ChangeRemoved, a[i], nil)
+		}
+	}
+
+	for j, key := range keysB {
+		if !matched[key] {
+			d.add(fmt.Sprintf("%s[%s=%s]", path, d.config.sliceKey, key), ChangeAdded, nil, b[j])
+		}
+	}
+}
+
+// sliceKeys returns the key value of each element, it fails
+// if an element is not an object, misses the key or if
+// keys are not unique
+func sliceKeys(items []any, field string) ([]string, bool) {
+	keys := make([]string, len(items))
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		value, ok := obj[field]
+		if !ok {
+			return nil, false
+		}
+
+		key := diffValue(value)
+		if seen[key] {
+			return nil, false
+		}
+		seen[key] = true
+		keys[i] = key
+	}
+	return keys, true
+}
@@ -0,0 +1,229 @@
+package print
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	type item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	type order struct {
+		Number   string   `json:"number"`
+		Password string   `json:"password"`
+		Items    []item   `json:"items"`
+		Tags     []string `json:"tags,omitempty"`
+	}
+
+	before := order{
+		Number:   "A-1",
+		Password: "secret",
+		Items:    []item{{ID: 1, Name: "pen"}, {ID: 2, Name: "ink"}},
+	}
+
+	after := order{
+		Number:   "A-2",
+		Password: "other",
+		Items:    []item{{ID: 2, Name: "ink"}, {ID: 3, Name: "pad"}},
+		Tags:     []string{"new"},
+	}
+
+	tests := []struct {
+		name string
+		opts []DiffOption
+		want []Change
+	}{
+		{
+			name: "match slices by index",
+			want: []Change{
+				{Path: "$.items[0].id", Type: ChangeUpdated, Old: 1, New: 2},
+				{Path: "$.items[0].name", Type: ChangeUpdated, Old: "pen", New: "ink"},
+				{Path: "$.items[1].id", Type: ChangeUpdated, Old: 2, New: 3},
+				{Path: "$.items[1].name", Type: ChangeUpdated, Old: "ink", New: "pad"},
+				{Path: "$.number", Type: ChangeUpdated, Old: "A-1", New: "A-2"},
+				{Path: "$.tags", Type: ChangeAdded, New: []any{"new"}},
+			},
+		},
+		{
+			name: "match slices by key",
+			opts: []DiffOption{WithSliceKey("id")},
+			want: []Change{
+				{Path: "$.items[id=1]", Type: ChangeRemoved, Old: map[string]any{"id": 1, "name": "pen"}},
+				{Path: "$.items[id=3]", Type: ChangeAdded, New: map[string]any{"id": 3, "name": "pad"}},
+				{Path: "$.number", Type: ChangeUpdated, Old: "A-1", New: "A-2"},
+				{Path: "$.tags", Type: ChangeAdded, New: []any{"new"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diff(before, after, tt.opts...)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+
+			if len(got.Changes) != len(tt.want) {
+				t.Fatalf("Diff() changes =\n%s\nwant %d changes", got.String(), len(tt.want))
+			}
+
+			for i, change := range got.Changes {
+				if change.Path != tt.want[i].Path || change.Type != tt.want[i].Type ||
+					diffValue(change.Old) != diffValue(tt.want[i].Old) ||
+					diffValue(change.New) != diffValue(tt.want[i].New) {
+					t.Errorf("Diff() change[%d] = %+v, want %+v", i, change, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDiffOutput(t *testing.T) {
+	a := map[string]any{"name": "john", "age": 30, "password": "secret"}
+	b := map[string]any{"name": "jane", "age": 30, "password": "changed", "x-role": "admin"}
+
+	got, err := Diff(a, b, WithDiffColor(false))
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+
+	wantList := `~ $.name: "john" -> "jane"
++ $["x-role"]: "admin"
+`
+	if got.String() != wantList {
+		t.Errorf("String() =\n%s\nwant:\n%s", got.String(), wantList)
+	}
+
+	wantUnified := `--- a
++++ b
+@@ -1,5 +1,6 @@
+ {
+ 	"age": 30,
+-	"name": "john",
+-	"password": "****"
++	"name": "jane",
++	"password": "****",
++	"x-role": "admin"
+ }
+`
+	if got.Unified() != wantUnified {
+		t.Errorf("Unified() =\n%s\nwant:\n%s", got.Unified(), wantUnified)
+	}
+
+	same, err := Diff(a, a)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if !same.Equal() || same.Unified() != "" {
+		t.Errorf("Diff() of same value should be equal, got %v", same.Changes)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	plain, _ := Diff(a, b)
+	if strings.Contains(plain.Unified(), ansiRed) {
+		t.Errorf("Unified() should not be coloured with NO_COLOR")
+	}
+
+	colored, _ := Diff(a, b, WithDiffColor(true))
+	if !strings.Contains(colored.Unified(), ansiRed) {
+		t.Errorf("Unified() should be coloured with WithDiffColor(true)")
+	}
+}
+
+func TestUnifiedDiffHunks(t *testing.T) {
+	before := strings.Join([]string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"}, "\n")
+	after := strings.Join([]string{"1", "two", "3", "4", "5", "6", "7", "8", "9", "10", "eleven", "12"}, "\n")
+
+	got := unifiedDiff(before, after, 1, false)
+	want := `--- a
++++ b
+@@ -1,3 +1,3 @@
+ 1
+-2
++two
+ 3
+@@ -10,3 +10,3 @@
+ 10
+-11
++eleven
+ 12
+`
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unifiedDiff() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestDiffLinesMinimal(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	random := func() []string {
+		lines := make([]string, rng.Intn(12))
+		for i := range lines {
+			lines[i] = strconv.Itoa(rng.Intn(4))
+		}
+		return lines
+	}
+
+	for range 500 {
+		a, b := random(), random()
+		ops := diffLines(a, b)
+
+		var gotA, gotB []string
+		edits := 0
+		for _, op := range ops {
+			if op.kind != '+' {
+				gotA = append(gotA, op.text)
+			}
+			if op.kind != '-' {
+				gotB = append(gotB, op.text)
+			}
+			if op.kind != ' ' {
+				edits++
+			}
+		}
+
+		if strings.Join(gotA, ",") != strings.Join(a, ",") || strings.Join(gotB, ",") != strings.Join(b, ",") {
+			t.Fatalf("diffLines(%v, %v) = %v does not rebuild the inputs", a, b, ops)
+		}
+		if want := len(a) + len(b) - 2*lcsLength(a, b); edits != want {
+			t.Fatalf("diffLines(%v, %v) has %d edits, want %d", a, b, edits, want)
+		}
+	}
+}
+
+func TestDiffLinesLarge(t *testing.T) {
+	a := make([]string, 10000)
+	b := make([]string, 10000)
+	for i := range a {
+		a[i] = strconv.Itoa(i)
+		b[i] = strconv.Itoa(i)
+	}
+	b[5000] = "changed"
+
+	ops := diffLines(a, b)
+	if len(ops) != 10001 {
+		t.Errorf("diffLines() = %d ops, want 10001", len(ops))
+	}
+}
+
+// lcsLength returns the length of the longest common subsequence
+func lcsLength(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	for i := range a {
+		cur := make([]int, len(b)+1)
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(prev[j+1], cur[j])
+			}
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -14,9 +14,15 @@
 //	// Print JSON, returns error message if fails
 //	str := print.MaybePrettyJSON(data)
 //
+//	// Single line JSON
+//	str, err := print.CompactJSON(data)
+//
 //	// Save to file
 //	err := print.SaveJSONFile("data.json", data)
 //
+//	// Newline delimited JSON from a slice, channel or iter.Seq
+//	err := print.WriteNDJSON(os.Stdout, records)
+//
 // Secure JSON (with masked sensitive data):
 //
 //	type User struct {
@@ -37,6 +43,50 @@
 //	// Save masked JSON to file
 //	err := print.SaveSecureJSONFile("user.json", user)
 //
+//	// Allowlist mode, only fields tagged `print:"safe"` or allowed by path,
+//	// key or type print in the clear
+//	print.PrintMasker = print.NewAllowMasker().AllowPaths("$.items[*].id")
+//
+//	// Compliance presets for card data, personal data and credentials
+//	print.PrintMasker = print.ChainMasker(print.PrintMasker, print.Presets(print.PCI, print.PII))
+//
+//	// Mask fields tagged `print:"internal"` or `print:"restricted"`
+//	// above the audience, e.g. GOPRINT_AUDIENCE=internal
+//	print.PrintMasker = print.ChainMasker(print.PrintMasker, print.NewLevelMasker(print.AudienceFromEnv()))
+//
+//	// Or per Printer, on top of PrintMasker
+//	p := print.NewPrinter(print.WithAudience(print.LevelInternal))
+//
+//	// Load masking rules from a JSON or YAML policy file
+//	masker, err := print.LoadPolicyMasker("masking.yaml")
+//	print.PrintMasker = masker
+//
+//	// Report the masked paths, the rules that matched and original lengths
+//	str, report, err := print.SecureJSONWithReport(user)
+//
+//	// Masking is applied to the Go value and to the normalized JSON tree,
+//	// secrets exposed through String or MarshalJSON are masked too
+//
+//	// Mask values by key name at any depth, e.g. in map[string]any payloads
+//	print.PrintMasker = print.ChainMasker(print.PrintMasker, print.NewKeyMasker(
+//	    print.FoldKey("client_secret"),
+//	    print.GlobKey("x-*-key"),
+//	))
+//
+//	// Mask secrets found inside string values, e.g. "Bearer ****"
+//	print.PrintMasker = print.ChainMasker(print.PrintMasker, print.NewDetectorMasker(print.DefaultDetectors...))
+//
+//	// Keyed fingerprints, e.g. "sk_…#a1b2c3", selectable by rule or struct tag
+//	fingerprint := print.MaskFingerprint(key)
+//	print.RegisterMaskStrategy("fingerprint", fingerprint) // `mask:"fingerprint"`
+//
+//	// Reversible AES-GCM tokens, revealed with print.Unmask or cmd/goprint-unmask
+//	encrypt, err := print.MaskEncryptFromEnv()
+//
+//	// Mask high entropy tokens, UUIDs, hex hashes and
+//	// encrypted tokens are allowed
+//	print.PrintMasker = print.ChainMasker(print.PrintMasker, print.NewDetectorMasker(print.NewEntropyDetector()))
+//
 // HTTP Request/Response printing:
 //
 //	// Print HTTP request as JSON
@@ -47,6 +97,63 @@
 //	resp, _ := http.Get("https://api.example.com")
 //	str := print.PrintHTTPResponse(resp)
 //
+// Table printing:
+//
+//	// Render a slice of structs or maps as an aligned table
+//	str := print.PrintTable(users, print.WithTableStyle(print.TableBox))
+//
+// CSV export:
+//
+//	// Save a slice of structs or maps as CSV, nested fields are flattened
+//	err := print.SaveSecureCSVFile("users.csv", users)
+//
+// logfmt output:
+//
+//	// Flatten into dot-path keys: address.city=Berlin name=john
+//	str := print.MaybeSecureLogfmt(user)
+//
+// Diff two values:
+//
+//	diff, err := print.Diff(before, after, print.WithSliceKey("id"))
+//	fmt.Print(diff.String())  // path level list
+//	fmt.Print(diff.Unified()) // coloured unified diff
+//
+// JSON Patch and Merge Patch:
+//
+//	ops, err := print.SecureJSONPatch(before, after)     // RFC 6902
+//	patch, err := print.SecureMergePatch(before, after) // RFC 7386
+//
+// Highlighting:
+//
+//	// Configure colour depth per printer, defaults can also be set
+//	// with GOPRINT_COLOR_DEPTH and GOPRINT_FORMATTER
+//	p := print.NewPrinter(print.WithColorDepth(print.Color256))
+//	str := p.MaybeHighlightJSON(data)
+//
+//	// Colours are only used when the writer is a terminal,
+//	// honouring NO_COLOR, FORCE_COLOR and TERM=dumb
+//	err := print.FprintHighlightJSON(os.Stderr, data)
+//
+//	// Use chroma styles and formatters, see the printchroma package.
+//	// Importing it also enables GOPRINT_STYLE for the defaults
+//	p := print.NewPrinter(print.WithHighlighter(printchroma.New(printchroma.WithStyle("github"))))
+//
+//	// Line numbers, depth guides and soft wrapping at the terminal width
+//	p := print.NewPrinter(print.WithLineNumbers(), print.WithDepthGuides(), print.WithWrap(0))
+//
+// HTML output:
+//
+//	// Self contained fragment with collapsible nodes and marked masked values
+//	fragment, err := print.SecureHTMLJSON(data)
+//
+// Annotated output:
+//
+//	// JSONC with Go types and sizes, collections truncated to 10 items
+//	str, err := print.NewPrinter(print.WithMaxItems(10)).AnnotatedJSON(data)
+//
+//	// Masked values are annotated without their type or length
+//	str, err := print.SecureAnnotatedJSON(data)
+//
 // Default Masked Fields:
 //   - Password/password
 //   - SigningKey/signing_key
@@ -55,8 +162,29 @@
 // Features:
 //   - Pretty prints JSON with proper indentation
 //   - Masks sensitive data (passwords, tokens, keys)
+//   - Key name masking rules for dynamic maps (exact, case-insensitive, glob, regex)
+//   - Secret detection inside string values (JWTs, bearer tokens, cloud keys, cards, emails)
+//   - Entropy based detection of unknown secrets
+//   - Keyed fingerprint masking to correlate values without revealing them
+//   - Reversible AES-GCM tokens with an Unmask function and CLI
+//   - Masking reports listing redacted paths and the rules that matched
+//   - Allowlist only masking for output leaving a trust boundary
+//   - Declarative masking policies loaded from JSON or YAML files
+//   - PCI, PII and auth compliance masking presets
+//   - Role-aware masking levels (public, internal, restricted) per audience
 //   - Saves JSON to files
+//   - Compact and newline delimited JSON (NDJSON) output
 //   - Prints HTTP requests and responses as JSON
+//   - Renders slices of structs or maps as tables
+//   - Exports slices of structs or maps as CSV/TSV
+//   - Flattens values into logfmt key=value pairs
+//   - Structural diff between two values
+//   - JSON Patch (RFC 6902) and JSON Merge Patch (RFC 7386) generation
+//   - Syntax highlighting with colour depth detection
+//   - Pluggable highlighters, a dependency free ANSI colouriser or chroma
+//   - Line numbers, depth guides and soft wrapping
+//   - HTML fragments with collapsible nodes for debug pages
+//   - Annotated JSONC output with Go types and sizes
 //   - Thread safe
 //   - Handles errors gracefully
 package print
@@ -0,0 +1,135 @@
+package print
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// EncryptedPrefix marks values encrypted by MaskEncrypt
+const EncryptedPrefix = "gpenc:"
+
+// EncryptionKeyEnv is the env var holding the base64
+// encoded key used by MaskEncryptFromEnv
+const EncryptionKeyEnv = "GOPRINT_ENCRYPTION_KEY"
+
+var encryptedToken = regexp.MustCompile(regexp.QuoteMeta(EncryptedPrefix) + `[A-Za-z0-9_-]+`)
+
+var errEncryptedToken = errors.New("invalid encrypted token")
+
+// MaskEncrypt encrypts values with AES-GCM under key into an
+// opaque token, e.g. "gpenc:Zm9v...". Unlike other strategies
+// values can be recovered by key holders with Unmask. The key
+// must be 16, 24 or 32 bytes long
+func MaskEncrypt(key []byte) (MaskStrategy, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return MaskFunc(func(value string) (string, error) {
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return empty, fmt.Errorf("error encrypting value: %w", err)
+		}
+
+		sealed := gcm.Seal(nonce, nonce, []byte(value), nil)
+		return EncryptedPrefix + base64.RawURLEncoding.EncodeToString(sealed), nil
+	}), nil
+}
+
+// MaskEncryptFromEnv creates an encryption strategy using
+// the key in the GOPRINT_ENCRYPTION_KEY env var
+func MaskEncryptFromEnv() (MaskStrategy, error) {
+	key, err := DecodeKey(os.Getenv(EncryptionKeyEnv))
+	if err != nil {
+		return nil, err
+	}
+	return MaskEncrypt(key)
+}
+
+// DecodeKey decodes a base64 encoded AES key
+func DecodeKey(encoded string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	if encoded == empty {
+		return nil, fmt.Errorf("encryption key not set, use %s", EncryptionKeyEnv)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding key: %w", err)
+	}
+	return key, nil
+}
+
+// Unmask replaces the tokens produced by MaskEncrypt in text with
+// the values they encrypt. Values are escaped for JSON strings so
+// dumps written by the secure printers stay valid JSON
+func Unmask(text string, key []byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return empty, err
+	}
+
+	var unmaskErr error
+	out := encryptedToken.ReplaceAllStringFunc(text, func(token string) string {
+		if unmaskErr != nil {
+			return token
+		}
+
+		value, err := decrypt(gcm, token)
+		if err != nil {
+			unmaskErr = err
+			return token
+		}
+		return escapeJSONString(value)
+	})
+
+	if unmaskErr != nil {
+		return empty, unmaskErr
+	}
+	return out, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func decrypt(gcm cipher.AEAD, token string) (string, error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token, EncryptedPrefix))
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return empty, errEncryptedToken
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return empty, fmt.Errorf("error decrypting value: %w", err)
+	}
+	return string(plain), nil
+}
+
+func escapeJSONString(s string) string {
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(s); err != nil {
+		return s
+	}
+
+	out := strings.TrimSuffix(buffer.String(), "\n")
+	return out[1 : len(out)-1]
+}
@@ -0,0 +1,95 @@
+package print
+
+import (
+	"math"
+	"regexp"
+)
+
+// Entropy detector defaults
+const (
+	DefaultEntropyMinLength = 24
+	DefaultEntropyThreshold = 4.0
+)
+
+// DefaultEntropyAllow lists known safe high entropy
+// tokens, UUIDs and hex encoded hashes
+var DefaultEntropyAllow = []*regexp.Regexp{
+	regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`),
+	regexp.MustCompile(`^[0-9a-fA-F]{32,128}$`),
+}
+
+// entropyToken matches base64 and url safe runs, the
+// candidates the detector computes the entropy of
+var entropyToken = regexp.MustCompile(`[A-Za-z0-9+/=_\-]+`)
+
+// EntropyDetector flags tokens with a high Shannon entropy as
+// likely secrets, catching vendor keys no pattern knows about
+type EntropyDetector struct {
+	// MinLength is the minimum length of a token to be checked
+	MinLength int
+	// Threshold is the minimum entropy in bits per character
+	Threshold float64
+	// Allow lists patterns of known safe tokens, matched
+	// against the whole token
+	Allow []*regexp.Regexp
+}
+
+// NewEntropyDetector creates an EntropyDetector using the
+// default length, threshold and allowlist
+func NewEntropyDetector() *EntropyDetector {
+	return &EntropyDetector{
+		MinLength: DefaultEntropyMinLength,
+		Threshold: DefaultEntropyThreshold,
+		Allow:     DefaultEntropyAllow,
+	}
+}
+
+// Name implements Detector, it returns entropy
+func (d *EntropyDetector) Name() string {
+	return "entropy"
+}
+
+// Detect implements Detector, it reports the high entropy tokens of s
+func (d *EntropyDetector) Detect(s string) [][2]int {
+	var spans [][2]int
+	for _, match := range entropyToken.FindAllStringIndex(s, -1) {
+		token := s[match[0]:match[1]]
+		if len(token) < d.MinLength || d.allowed(token) {
+			continue
+		}
+		if ShannonEntropy(token) >= d.Threshold {
+			spans = append(spans, [2]int{match[0], match[1]})
+		}
+	}
+	return spans
+}
+
+func (d *EntropyDetector) allowed(token string) bool {
+	for _, re := range d.Allow {
+		if re.MatchString(token) {
+			return true
+		}
+	}
+	return false
+}
+
+// ShannonEntropy returns the entropy of s in bits per character
+func ShannonEntropy(s string) float64 {
+	if s == empty {
+		return 0
+	}
+
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
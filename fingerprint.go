@@ -0,0 +1,65 @@
+package print
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// FingerprintKeyEnv is the env var holding the
+// per deployment key used by MaskFingerprintFromEnv
+const FingerprintKeyEnv = "GOPRINT_FINGERPRINT_KEY"
+
+const (
+	fingerprintLength    = 6
+	fingerprintMaxPrefix = 8
+)
+
+// MaskFingerprint replaces values with a short HMAC-SHA256
+// fingerprint keyed by key, e.g. "sk_…#a1b2c3". Equal values
+// produce equal fingerprints so they can be correlated across
+// log lines without being revealed. Vendor prefixes like sk_
+// or ghp_ are kept
+func MaskFingerprint(key []byte) MaskStrategy {
+	return MaskFunc(func(value string) (string, error) {
+		if value == empty {
+			return empty, nil
+		}
+
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(value))
+		sum := hex.EncodeToString(mac.Sum(nil))
+
+		return fingerprintPrefix(value) + "…#" + sum[:fingerprintLength], nil
+	})
+}
+
+// MaskFingerprintFromEnv creates a fingerprint strategy keyed
+// by the GOPRINT_FINGERPRINT_KEY env var
+func MaskFingerprintFromEnv() (MaskStrategy, error) {
+	key := os.Getenv(FingerprintKeyEnv)
+	if key == empty {
+		return nil, fmt.Errorf("fingerprint key not set, use %s", FingerprintKeyEnv)
+	}
+	return MaskFingerprint([]byte(key)), nil
+}
+
+// fingerprintPrefix returns the vendor prefix of value, a short
+// run of letters followed by _ or -, e.g. sk_ or xoxb-
+func fingerprintPrefix(value string) string {
+	i := strings.IndexAny(value, "_-")
+	if i <= 0 || i > fingerprintMaxPrefix || i >= len(value)/2 {
+		return empty
+	}
+
+	for _, r := range value[:i] {
+		if !unicode.IsLetter(r) {
+			return empty
+		}
+	}
+	return value[:i+1]
+}
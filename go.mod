@@ -9,9 +9,12 @@ require (
 	github.com/goliatone/go-masker v0.1.0
 	github.com/google/uuid v1.6.0
 	golang.org/x/oauth2 v0.28.0
+	golang.org/x/term v0.30.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/dlclark/regexp2 v1.11.5 // indirect
 	github.com/showa-93/go-mask v0.6.2 // indirect
+	golang.org/x/sys v0.31.0 // indirect
 )
@@ -0,0 +1,128 @@
+package print
+
+import (
+	"io"
+	"strings"
+)
+
+// Highlighter colours a stream of JSON tokens. ANSIHighlighter is
+// used by default, the printchroma package provides one backed by
+// chroma styles and formatters
+type Highlighter interface {
+	Highlight(w io.Writer, tokens []Token) error
+}
+
+// ANSIPalette holds the escape sequences used for each kind of token
+type ANSIPalette struct {
+	Key         string
+	String      string
+	Number      string
+	Bool        string
+	Null        string
+	Punctuation string
+	Masked      string
+	Gutter      string
+	Comment     string
+}
+
+// Palette16 uses the basic terminal colours
+var Palette16 = ANSIPalette{
+	Key:     ansiBlue,
+	String:  ansiGreen,
+	Number:  ansiMagenta,
+	Bool:    ansiYellow,
+	Null:    ansiDim,
+	Masked:  ansiDim + ansiRed,
+	Gutter:  ansiDim,
+	Comment: ansiDim,
+}
+
+// Palette256 uses the 256 colour palette
+var Palette256 = ANSIPalette{
+	Key:         "\x1b[38;5;110m",
+	String:      "\x1b[38;5;150m",
+	Number:      "\x1b[38;5;176m",
+	Bool:        "\x1b[38;5;179m",
+	Null:        "\x1b[38;5;244m",
+	Punctuation: "\x1b[38;5;250m",
+	Masked:      ansiDim + "\x1b[38;5;167m",
+	Gutter:      "\x1b[38;5;240m",
+	Comment:     "\x1b[38;5;243m",
+}
+
+// PaletteTrueColor uses 24 bit colours
+var PaletteTrueColor = ANSIPalette{
+	Key:         "\x1b[38;2;136;192;208m",
+	String:      "\x1b[38;2;163;190;140m",
+	Number:      "\x1b[38;2;180;142;173m",
+	Bool:        "\x1b[38;2;129;161;193m",
+	Null:        "\x1b[38;2;129;161;193m",
+	Punctuation: "\x1b[38;2;236;239;244m",
+	Masked:      ansiDim + "\x1b[38;2;191;97;106m",
+	Gutter:      "\x1b[38;2;76;86;106m",
+	Comment:     "\x1b[38;2;97;110;136m",
+}
+
+// ANSIHighlighter writes ANSI escape sequences directly while
+// walking the tokens, it is the default Highlighter
+type ANSIHighlighter struct {
+	Palette ANSIPalette
+}
+
+// NewANSIHighlighter returns an ANSIHighlighter using
+// the palette that best matches the colour depth
+func NewANSIHighlighter(depth ColorDepth) *ANSIHighlighter {
+	switch {
+	case depth >= TrueColor:
+		return &ANSIHighlighter{Palette: PaletteTrueColor}
+	case depth >= Color256:
+		return &ANSIHighlighter{Palette: Palette256}
+	default:
+		return &ANSIHighlighter{Palette: Palette16}
+	}
+}
+
+// Highlight implements Highlighter
+func (h *ANSIHighlighter) Highlight(w io.Writer, tokens []Token) error {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString(colorize(token.Value, h.color(token), true))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (h *ANSIHighlighter) color(token Token) string {
+	if token.Masked {
+		return h.Palette.Masked
+	}
+
+	switch token.Kind {
+	case TokenKey:
+		return h.Palette.Key
+	case TokenString:
+		return h.Palette.String
+	case TokenNumber:
+		return h.Palette.Number
+	case TokenBool:
+		return h.Palette.Bool
+	case TokenNull:
+		return h.Palette.Null
+	case TokenPunctuation:
+		return h.Palette.Punctuation
+	case TokenLineNumber, TokenGuide:
+		return h.Palette.Gutter
+	case TokenComment:
+		return h.Palette.Comment
+	default:
+		return empty
+	}
+}
+
+func joinTokens(tokens []Token) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString(token.Value)
+	}
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package print
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestANSIHighlighter(t *testing.T) {
+	tokens := []Token{
+		{Kind: TokenPunctuation, Value: "{"},
+		{Kind: TokenWhitespace, Value: "\n\t"},
+		{Kind: TokenKey, Value: `"a"`},
+		{Kind: TokenPunctuation, Value: ":"},
+		{Kind: TokenWhitespace, Value: " "},
+		{Kind: TokenString, Value: `"****"`, Masked: true},
+		{Kind: TokenPunctuation, Value: ","},
+		{Kind: TokenWhitespace, Value: "\n\t"},
+		{Kind: TokenKey, Value: `"b"`},
+		{Kind: TokenPunctuation, Value: ":"},
+		{Kind: TokenWhitespace, Value: " "},
+		{Kind: TokenNull, Value: "null"},
+		{Kind: TokenWhitespace, Value: "\n"},
+		{Kind: TokenPunctuation, Value: "}"},
+	}
+
+	tests := []struct {
+		name  string
+		depth ColorDepth
+		want  string
+	}{
+		{
+			name:  "16 colours",
+			depth: Color16,
+			want:  "{\n\t\x1b[34m\"a\"\x1b[0m: \x1b[2m\x1b[31m\"****\"\x1b[0m,\n\t\x1b[34m\"b\"\x1b[0m: \x1b[2mnull\x1b[0m\n}",
+		},
+		{
+			name:  "256 colours",
+			depth: Color256,
+			want:  "\x1b[38;5;250m{\x1b[0m\n\t\x1b[38;5;110m\"a\"\x1b[0m\x1b[38;5;250m:\x1b[0m \x1b[2m\x1b[38;5;167m\"****\"\x1b[0m\x1b[38;5;250m,\x1b[0m\n\t\x1b[38;5;110m\"b\"\x1b[0m\x1b[38;5;250m:\x1b[0m \x1b[38;5;244mnull\x1b[0m\n\x1b[38;5;250m}\x1b[0m",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+			if err := NewANSIHighlighter(tt.depth).Highlight(buffer, tokens); err != nil {
+				t.Fatalf("Highlight() error = %v", err)
+			}
+			if got := buffer.String(); got != tt.want {
+				t.Errorf("Highlight() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrinterWithHighlighter(t *testing.T) {
+	data := map[string]any{"password": "secret", "n": 1}
+
+	p := NewPrinter(WithHighlighter(NewANSIHighlighter(Color16)), WithColorMode(ColorAlways))
+
+	got := p.MaybeHighlightJSON(data)
+	want := "{\n\t\x1b[34m\"n\"\x1b[0m: \x1b[35m1\x1b[0m,\n\t\x1b[34m\"password\"\x1b[0m: \x1b[32m\"secret\"\x1b[0m\n}\n"
+	if got != want {
+		t.Errorf("MaybeHighlightJSON() = %q, want %q", got, want)
+	}
+
+	got = p.MaybeSecureHighlightJSON(data)
+	want = "{\n\t\x1b[34m\"n\"\x1b[0m: \x1b[35m1\x1b[0m,\n\t\x1b[34m\"password\"\x1b[0m: \x1b[2m\x1b[31m\"****\"\x1b[0m\n}\n"
+	if got != want {
+		t.Errorf("MaybeSecureHighlightJSON() = %q, want %q", got, want)
+	}
+
+	plain := NewPrinter(WithHighlighter(NewANSIHighlighter(Color16)), WithColorMode(ColorNever))
+	if got := plain.MaybeHighlightJSON(data); got != MaybePrettyJSON(data) {
+		t.Errorf("MaybeHighlightJSON() without colour = %q", got)
+	}
+}
+
+func TestPrinterDefaultHighlighter(t *testing.T) {
+	t.Setenv(ColorDepthEnv, "256")
+
+	data := map[string]any{"n": 1}
+	got := NewPrinter(WithColorMode(ColorAlways)).MaybeHighlightJSON(data)
+
+	buffer := new(bytes.Buffer)
+	tokens := jsonTokens(map[string]any{"n": json.Number("1")}, nil)
+	if err := (&ANSIHighlighter{Palette: Palette256}).Highlight(buffer, tokens); err != nil {
+		t.Fatalf("Highlight() error = %v", err)
+	}
+
+	if got != buffer.String() {
+		t.Errorf("default highlighter should use the 256 colour palette, got %q", got)
+	}
+}
@@ -0,0 +1,234 @@
+package print
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+const htmlClass = "goprint"
+
+// HTMLPalette holds the CSS declarations used for each
+// kind of token in HTML output, e.g. "color:#88c0d0"
+type HTMLPalette struct {
+	Background  string
+	Key         string
+	String      string
+	Number      string
+	Bool        string
+	Null        string
+	Punctuation string
+	Comment     string
+	Masked      string
+}
+
+// DefaultHTMLPalette uses the nord colours
+var DefaultHTMLPalette = HTMLPalette{
+	Background:  "color:#d8dee9;background-color:#2e3440",
+	Key:         "color:#88c0d0",
+	String:      "color:#a3be8c",
+	Number:      "color:#b48ead",
+	Bool:        "color:#81a1c1",
+	Null:        "color:#81a1c1",
+	Punctuation: "color:#eceff4",
+	Comment:     "color:#616e88;font-style:italic",
+	Masked:      "color:#bf616a;font-style:italic",
+}
+
+// WithHTMLPalette sets the colours used by HTML output,
+// e.g. printchroma.HTMLPalette("github")
+func WithHTMLPalette(palette HTMLPalette) Option {
+	return func(p *Printer) {
+		p.html = palette
+	}
+}
+
+// HTMLJSON renders data as a self contained HTML fragment using the
+// default Printer configuration. Objects and arrays are collapsible
+func HTMLJSON(data any) (string, error) {
+	return NewPrinter().HTMLJSON(data)
+}
+
+// SecureHTMLJSON renders masked data as a self contained HTML
+// fragment, masked values are clearly marked
+func SecureHTMLJSON(data any) (string, error) {
+	return NewPrinter().SecureHTMLJSON(data)
+}
+
+// HTMLJSON renders data as a self contained HTML fragment. Colours
+// are taken from the HTML palette, objects and arrays are rendered
+// as <details> elements so they can be collapsed without JS
+func (p *Printer) HTMLJSON(data any) (string, error) {
+	tree, err := jsonTree(data)
+	if err != nil {
+		return empty, err
+	}
+	return p.renderHTML(tree, nil)
+}
+
+// SecureHTMLJSON will mask sensitive data and render it as a
+// self contained HTML fragment, masked values are marked
+func (p *Printer) SecureHTMLJSON(data any) (string, error) {
+	tree, masked, err := secureTree(p.secureMasker(), data)
+	if err != nil {
+		return empty, err
+	}
+	return p.renderHTML(tree, masked)
+}
+
+func (p *Printer) renderHTML(tree any, masked map[string]bool) (string, error) {
+	r := &htmlRenderer{masked: masked, glyph: p.maskGlyph}
+	r.b.WriteString(htmlStyles(p.html))
+	r.b.WriteString(`<div class="` + htmlClass + `">`)
+	r.value(rootPath, empty, tree, false)
+	r.b.WriteString(`</div>`)
+
+	return r.b.String(), nil
+}
+
+type htmlRenderer struct {
+	b      strings.Builder
+	masked map[string]bool
+	glyph  string
+}
+
+func (r *htmlRenderer) span(class, value string) {
+	r.b.WriteString(`<span class="gp-` + class + `">` + html.EscapeString(value) + `</span>`)
+}
+
+// value renders a single line, key is the already encoded
+// key of the value or empty for array items and the root
+func (r *htmlRenderer) value(path, key string, v any, comma bool) {
+	switch value := v.(type) {
+	case map[string]any:
+		if len(value) == 0 {
+			r.scalar(path, key, "{}", "p", comma)
+			return
+		}
+		r.open(key, "{", plural(len(value), "key"))
+		keys := sortedKeys(value)
+		for i, k := range keys {
+			r.value(keyPath(path, k), marshalScalar(k), value[k], i < len(keys)-1)
+		}
+		r.close("}", comma)
+
+	case []any:
+		if len(value) == 0 {
+			r.scalar(path, key, "[]", "p", comma)
+			return
+		}
+		r.open(key, "[", plural(len(value), "item"))
+		for i, item := range value {
+			r.value(indexPath(path, i), empty, item, i < len(value)-1)
+		}
+		r.close("]", comma)
+
+	default:
+		r.scalar(path, key, marshalScalar(value), htmlTokenClass(scalarKind(value)), comma)
+	}
+}
+
+func (r *htmlRenderer) key(key string) {
+	if key == empty {
+		return
+	}
+	r.span("k", key)
+	r.span("p", ": ")
+}
+
+func (r *htmlRenderer) scalar(path, key, value, class string, comma bool) {
+	r.b.WriteString(`<div class="gp-line">`)
+	r.key(key)
+
+	if r.masked[path] {
+		r.b.WriteString(`<span class="gp-masked" title="masked value" data-path="` + html.EscapeString(path) + `">`)
+		if r.glyph != empty {
+			r.b.WriteString(html.EscapeString(r.glyph + " "))
+		}
+		r.b.WriteString(html.EscapeString(value) + `</span>`)
+	} else {
+		r.span(class, value)
+	}
+
+	if comma {
+		r.span("p", ",")
+	}
+	r.b.WriteString(`</div>`)
+}
+
+func (r *htmlRenderer) open(key, bracket, size string) {
+	r.b.WriteString(`<details open><summary>`)
+	r.key(key)
+	r.span("p", bracket)
+	r.span("size", size)
+	r.b.WriteString(`</summary><div class="gp-children">`)
+}
+
+func (r *htmlRenderer) close(bracket string, comma bool) {
+	r.b.WriteString(`</div>`)
+	r.span("p", bracket)
+	if comma {
+		r.span("p", ",")
+	}
+	r.b.WriteString(`</details>`)
+}
+
+func htmlTokenClass(kind TokenKind) string {
+	switch kind {
+	case TokenString:
+		return "s"
+	case TokenNumber:
+		return "n"
+	case TokenBool:
+		return "b"
+	case TokenNull:
+		return "null"
+	default:
+		return "p"
+	}
+}
+
+// htmlStyles returns a <style> block scoped to the
+// fragment using the colours of the palette
+func htmlStyles(palette HTMLPalette) string {
+	rules := []struct {
+		selector string
+		css      string
+	}{
+		{"", palette.Background},
+		{" .gp-k", palette.Key},
+		{" .gp-s", palette.String},
+		{" .gp-n", palette.Number},
+		{" .gp-b", palette.Bool},
+		{" .gp-null", palette.Null},
+		{" .gp-p", palette.Punctuation},
+		{" .gp-size", palette.Comment},
+		{" .gp-masked", palette.Masked},
+	}
+
+	var b strings.Builder
+	b.WriteString("<style>")
+	b.WriteString("." + htmlClass + "{font-family:monospace;white-space:pre;padding:1em;overflow:auto}")
+	b.WriteString("." + htmlClass + " summary{list-style:none;cursor:pointer}")
+	b.WriteString("." + htmlClass + " summary::-webkit-details-marker{display:none}")
+	b.WriteString("." + htmlClass + " .gp-children{padding-left:4ch}")
+	b.WriteString("." + htmlClass + " .gp-size{margin-left:1ch}")
+	b.WriteString("." + htmlClass + " details[open]>summary .gp-size{display:none}")
+	b.WriteString("." + htmlClass + " .gp-masked{text-decoration:underline dotted}")
+
+	for _, rule := range rules {
+		if rule.css != empty {
+			b.WriteString("." + htmlClass + rule.selector + "{" + rule.css + "}")
+		}
+	}
+
+	b.WriteString("</style>")
+	return b.String()
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
@@ -0,0 +1,55 @@
+package print
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTMLJSON(t *testing.T) {
+	data := map[string]any{
+		"name":     "<john>",
+		"password": "secret123",
+		"roles":    []string{"admin"},
+		"meta":     map[string]any{},
+	}
+
+	p := NewPrinter(WithHTMLPalette(HTMLPalette{Key: "color:#000080"}), WithMaskGlyph("🔒"))
+
+	got, err := p.HTMLJSON(data)
+	if err != nil {
+		t.Fatalf("HTMLJSON() error = %v", err)
+	}
+
+	contains := []string{
+		`<style>.goprint{`,
+		`.goprint .gp-k{color:#000080}`,
+		`<div class="goprint"><details open><summary><span class="gp-p">{</span><span class="gp-size">4 keys</span></summary>`,
+		`<span class="gp-k">&#34;name&#34;</span><span class="gp-p">: </span><span class="gp-s">&#34;\u003cjohn\u003e&#34;</span>`,
+		`<span class="gp-s">&#34;secret123&#34;</span>`,
+		`<span class="gp-p">[</span><span class="gp-size">1 item</span>`,
+		`<span class="gp-p">{}</span>`,
+	}
+	for _, want := range contains {
+		if !strings.Contains(got, want) {
+			t.Errorf("HTMLJSON() missing %s\ngot: %s", want, got)
+		}
+	}
+
+	if strings.Contains(got, "gp-masked\"") {
+		t.Errorf("HTMLJSON() should not mark values as masked")
+	}
+
+	secure, err := p.SecureHTMLJSON(data)
+	if err != nil {
+		t.Fatalf("SecureHTMLJSON() error = %v", err)
+	}
+
+	if strings.Contains(secure, "secret123") {
+		t.Errorf("SecureHTMLJSON() leaked secret: %s", secure)
+	}
+
+	want := `<span class="gp-masked" title="masked value" data-path="$.password">🔒 &#34;****&#34;</span>`
+	if !strings.Contains(secure, want) {
+		t.Errorf("SecureHTMLJSON() missing masked marker %s\ngot: %s", want, secure)
+	}
+}
@@ -4,9 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
-
-	"github.com/alecthomas/chroma/v2/quick"
+	"strings"
 )
 
 const (
@@ -17,18 +17,41 @@ const (
 
 // PrettyJSON will pretty print as a JSON string
 func PrettyJSON(data any) (string, error) {
+	buffer := new(bytes.Buffer)
+	if err := encodeJSON(buffer, data, tab); err != nil {
+		return empty, err
+	}
+	return buffer.String(), nil
+}
 
-	safeData := safeToJSON(data)
-
+// CompactJSON will print data as a single line JSON string
+func CompactJSON(data any) (string, error) {
 	buffer := new(bytes.Buffer)
-	encoder := json.NewEncoder(buffer)
-	encoder.SetIndent(empty, tab)
+	if err := encodeJSON(buffer, data, empty); err != nil {
+		return empty, err
+	}
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
 
-	err := encoder.Encode(safeData)
+// MaybeCompactJSON will return a single line JSON string, in
+// case of an error it will return the message: error printing
+func MaybeCompactJSON(data any) string {
+	out, err := CompactJSON(data)
 	if err != nil {
-		return empty, err
+		return fmt.Sprintf("error printing: %s", err)
 	}
-	return buffer.String(), nil
+	return out
+}
+
+func encodeJSON(w io.Writer, data any, indent string) error {
+	safeData := safeToJSON(data)
+
+	encoder := json.NewEncoder(w)
+	if indent != empty {
+		encoder.SetIndent(empty, indent)
+	}
+
+	return encoder.Encode(safeData)
 }
 
 // MaybePrettyJSON will return a JSON string, in case
@@ -42,58 +65,63 @@ func MaybePrettyJSON(data any) string {
 	return out
 }
 
+// MaybeHighlightJSON will return highlighted JSON using
+// the default Printer configuration
 func MaybeHighlightJSON(data any) string {
-	out, err := HighlightJSON(data)
-	if err != nil {
-		return fmt.Sprintf("error printing: %s", err)
-	}
-	return out
+	return NewPrinter().MaybeHighlightJSON(data)
 }
 
+// HighlightJSON will pretty print data as highlighted JSON
+// using the default Printer configuration
 func HighlightJSON(data any) (string, error) {
-	out, err := PrettyJSON(data)
-	if err != nil {
-		return "", err
-	}
+	return NewPrinter().HighlightJSON(data)
+}
 
-	var buf bytes.Buffer
-	err = quick.Highlight(&buf, out, "json", "terminal16m", "nord")
-	if err != nil {
-		return "", fmt.Errorf("error highlighting: %w", err)
-	}
+// FprintHighlightJSON will write data as highlighted JSON to w
+// using the default Printer configuration. Colours are only
+// used when w is a terminal
+func FprintHighlightJSON(w io.Writer, data any) error {
+	return NewPrinter().FprintHighlightJSON(w, data)
+}
 
-	return buf.String(), nil
+// FprintSecureHighlightJSON will write masked data as highlighted
+// JSON to w using the default Printer configuration
+func FprintSecureHighlightJSON(w io.Writer, data any) error {
+	return NewPrinter().FprintSecureHighlightJSON(w, data)
 }
 
+// MaybeSecureHighlightJSON will return masked highlighted
+// JSON using the default Printer configuration
 func MaybeSecureHighlightJSON(data any) string {
-	out, err := SecureHighlightJSON(data)
-	if err != nil {
-		return fmt.Sprintf("error printing: %s", err)
-	}
-	return out
+	return NewPrinter().MaybeSecureHighlightJSON(data)
 }
 
+// SecureHighlightJSON will mask sensitive data and print it as
+// highlighted JSON using the default Printer configuration
 func SecureHighlightJSON(data any) (string, error) {
-	out, err := SecureJSON(data)
+	return NewPrinter().SecureHighlightJSON(data)
+}
+
+func SecureJSON(data any) (string, error) {
+	_, tree, err := maskTree(data)
 	if err != nil {
 		return "", err
 	}
-
-	var buf bytes.Buffer
-	err = quick.Highlight(&buf, out, "json", "terminal16m", "nord")
+	out, err := PrettyJSON(tree)
 	if err != nil {
-		return "", fmt.Errorf("error highlighting: %w", err)
+		return "", fmt.Errorf("error printing data: %w", err)
 	}
-
-	return buf.String(), nil
+	return out, nil
 }
 
-func SecureJSON(data any) (string, error) {
-	maskedData, err := PrintMasker.Mask(data)
+// SecureCompactJSON will mask sensitive data and print
+// it as a single line JSON string
+func SecureCompactJSON(data any) (string, error) {
+	_, tree, err := maskTree(data)
 	if err != nil {
-		return "", fmt.Errorf("error masking data: %w", err)
+		return "", err
 	}
-	out, err := PrettyJSON(maskedData)
+	out, err := CompactJSON(tree)
 	if err != nil {
 		return "", fmt.Errorf("error printing data: %w", err)
 	}
@@ -103,8 +131,7 @@ func SecureJSON(data any) (string, error) {
 func MaybeSecureJSON(data any) string {
 	out, err := SecureJSON(data)
 	if err != nil {
-		fmt.Printf("secure JSON error parse: %s", data)
-		return "error printing"
+		return fmt.Sprintf("error printing: %s", err)
 	}
 	return out
 }
@@ -1,6 +1,7 @@
 package print
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -23,14 +24,15 @@ func safeToJSON(v any) any {
 		return safeToJSON(val.Elem().Interface())
 	}
 
+	// json.Number is a Stringer but we want to keep it as a number
+	if n, ok := v.(json.Number); ok {
+		return n
+	}
+
 	if m, ok := v.(json.Marshaler); ok {
 		b, err := m.MarshalJSON()
 		if err == nil {
-			var str string
-			if err := json.Unmarshal(b, &str); err == nil {
-				return str
-			}
-			return string(b)
+			return marshaledValue(b)
 		}
 	}
 
@@ -84,31 +86,17 @@ func safeToJSON(v any) any {
 		for i := range t.NumField() {
 			field := t.Field(i)
 
-			// skip unexported fields
-			if field.PkgPath != "" {
+			fieldName, omitEmpty, ok := jsonFieldName(field)
+			if !ok {
 				continue
 			}
 
-			fieldName := field.Name
-			jsonTag := field.Tag.Get("json")
-			if jsonTag != "" {
-				parts := strings.Split(jsonTag, ",")
-				if parts[0] != "-" {
-					if parts[0] != "" {
-						fieldName = parts[0]
-					}
-				} else {
-					// skip json:"-" fields
-					continue
-				}
-			}
-
 			fieldValue := val.Field(i).Interface()
 			safeValue := safeToJSON(fieldValue)
 
 			// Handle omitempty logic
 			var shouldOmit bool
-			if jsonTag != "" && strings.Contains(jsonTag, "omitempty") {
+			if omitEmpty {
 				shouldOmit = isEmptyValue(safeValue)
 			}
 
@@ -123,6 +111,73 @@ func safeToJSON(v any) any {
 	}
 }
 
+// marshaledValue decodes MarshalJSON output. Strings are kept as
+// strings, objects, arrays and literals are kept as JSON values so
+// the masked tree sees their keys, and they print, diff and patch
+// like any other value. Invalid output is kept as a raw string
+func marshaledValue(b []byte) any {
+	var str string
+	if bytes.HasPrefix(b, []byte(`"`)) && json.Unmarshal(b, &str) == nil {
+		return str
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err == nil && !decoder.More() {
+		return value
+	}
+	return string(b)
+}
+
+// jsonFieldName returns the name used to encode a struct field,
+// whether it is tagged omitempty and false if the field should be
+// skipped (unexported or tagged with json:"-")
+func jsonFieldName(field reflect.StructField) (string, bool, bool) {
+	// skip unexported fields
+	if field.PkgPath != "" {
+		return empty, false, false
+	}
+
+	name := field.Name
+	jsonTag := field.Tag.Get("json")
+	if jsonTag == "" {
+		return name, false, true
+	}
+
+	parts := strings.Split(jsonTag, ",")
+	if parts[0] == "-" {
+		// skip json:"-" fields
+		return empty, false, false
+	}
+
+	if parts[0] != "" {
+		name = parts[0]
+	}
+
+	return name, strings.Contains(jsonTag, "omitempty"), true
+}
+
+// jsonFieldNames returns the encoded field names of a struct type
+// in declaration order
+func jsonFieldNames(t reflect.Type) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	names := make([]string, 0, t.NumField())
+	for i := range t.NumField() {
+		if name, _, ok := jsonFieldName(t.Field(i)); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 func isEmptyValue(v any) bool {
 	if v == nil {
 		return true
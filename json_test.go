@@ -340,3 +340,65 @@ func normalizeJSON(s string) string {
 	normalized, _ := json.MarshalIndent(temp, "", "\t")
 	return string(normalized)
 }
+
+func TestCompactJSON(t *testing.T) {
+	user := TestUser{
+		ID:       uuid.MustParse("96703BDA-680E-4732-AE86-B755AAA8042F"),
+		Username: "john",
+		Password: "secret123",
+	}
+
+	got, err := CompactJSON(user)
+	if err != nil {
+		t.Fatalf("CompactJSON() error = %v", err)
+	}
+
+	want := `{"api_key":"","id":"96703bda-680e-4732-ae86-b755aaa8042f","password":"secret123","username":"john"}`
+	if got != want {
+		t.Errorf("CompactJSON() = %v, want %v", got, want)
+	}
+
+	got, err = SecureCompactJSON(user)
+	if err != nil {
+		t.Fatalf("SecureCompactJSON() error = %v", err)
+	}
+
+	want = `{"api_key":"********************************","id":"96703bda-680e-4732-ae86-b755aaa8042f","password":"****","username":"john"}`
+	if got != want {
+		t.Errorf("SecureCompactJSON() = %v, want %v", got, want)
+	}
+}
+
+type rawMarshaler string
+
+func (m rawMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte(m), nil
+}
+
+func TestPrettyJSONMarshaler(t *testing.T) {
+	tests := []struct {
+		name string
+		data rawMarshaler
+		want string
+	}{
+		{name: "string", data: `"2024-01-02"`, want: `"2024-01-02"`},
+		{name: "object", data: `{"id":1,"tags":["a"]}`, want: `{"id":1,"tags":["a"]}`},
+		{name: "array", data: `[1,2]`, want: `[1,2]`},
+		{name: "number", data: `12345678901234567890`, want: `12345678901234567890`},
+		{name: "literal", data: `true`, want: `true`},
+		{name: "invalid", data: `{"id":`, want: `"{\"id\":"`},
+		{name: "trailing data", data: `{} {}`, want: `"{} {}"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CompactJSON(map[string]any{"value": tt.data})
+			if err != nil {
+				t.Fatalf("CompactJSON() error = %v", err)
+			}
+			if want := `{"value":` + tt.want + `}`; got != want {
+				t.Errorf("CompactJSON() = %s, want %s", got, want)
+			}
+		})
+	}
+}
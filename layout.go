@@ -0,0 +1,162 @@
+package print
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	indentUnit   = "    "
+	guideUnit    = "│   "
+	minWrapWidth = 10
+)
+
+// layout decorates tokens with line numbers and depth guides
+// and soft wraps long strings. When enabled, tab indentation
+// is replaced with spaces so widths can be computed
+type layout struct {
+	lineNumbers bool
+	guides      bool
+	wrap        bool
+	width       int
+}
+
+func (l layout) enabled() bool {
+	return l.lineNumbers || l.guides || l.wrap
+}
+
+type layoutLine struct {
+	depth  int
+	tokens []Token
+}
+
+func (l layout) apply(tokens []Token) []Token {
+	lines := splitTokenLines(tokens)
+
+	width := l.width
+	if l.wrap && width <= 0 {
+		width = terminalWidth()
+	}
+
+	digits := len(fmt.Sprint(len(lines)))
+
+	var out []Token
+	for i, line := range lines {
+		if i > 0 {
+			out = append(out, Token{Kind: TokenWhitespace, Value: "\n"})
+		}
+
+		if len(line.tokens) == 0 && i == len(lines)-1 {
+			break
+		}
+
+		gutter := func(n int) []Token {
+			if !l.lineNumbers {
+				return nil
+			}
+			number := strings.Repeat(" ", digits)
+			if n > 0 {
+				number = fmt.Sprintf("%*d", digits, n)
+			}
+			return []Token{{Kind: TokenLineNumber, Value: number + " │ "}}
+		}
+
+		out = append(out, gutter(i+1)...)
+		out = append(out, l.indent(line.depth)...)
+
+		if !l.wrap {
+			out = append(out, line.tokens...)
+			continue
+		}
+
+		prefix := tokensWidth(gutter(i + 1))
+		continuation := prefix + (line.depth+1)*utf8.RuneCountInString(indentUnit)
+		limit := max(width, continuation+minWrapWidth)
+
+		used := prefix + line.depth*utf8.RuneCountInString(indentUnit)
+		for _, token := range line.tokens {
+			for token.Kind == TokenString && used+utf8.RuneCountInString(token.Value) > limit {
+				// split the string and continue on the next line
+				room := max(limit-used, 1)
+				runes := []rune(token.Value)
+				// prefer breaking after a space
+				if space := lastSpace(runes[:room]); space > room/2 {
+					room = space + 1
+				}
+				head := token
+				head.Value = string(runes[:room])
+				token.Value = string(runes[room:])
+
+				out = append(out, head, Token{Kind: TokenWhitespace, Value: "\n"})
+				out = append(out, gutter(0)...)
+				out = append(out, l.indent(line.depth+1)...)
+				used = continuation
+			}
+			out = append(out, token)
+			used += utf8.RuneCountInString(token.Value)
+		}
+	}
+
+	return out
+}
+
+func (l layout) indent(depth int) []Token {
+	if depth == 0 {
+		return nil
+	}
+
+	if l.guides {
+		return []Token{{Kind: TokenGuide, Value: strings.Repeat(guideUnit, depth)}}
+	}
+	return []Token{{Kind: TokenWhitespace, Value: strings.Repeat(indentUnit, depth)}}
+}
+
+// splitTokenLines splits tokens on new lines, the tab
+// indentation of each line is stored as its depth
+func splitTokenLines(tokens []Token) []layoutLine {
+	lines := []layoutLine{{}}
+	for _, token := range tokens {
+		if token.Kind != TokenWhitespace || !strings.Contains(token.Value, "\n") {
+			current := &lines[len(lines)-1]
+			current.tokens = append(current.tokens, token)
+			continue
+		}
+
+		parts := strings.Split(token.Value, "\n")
+		for i, part := range parts {
+			if i > 0 {
+				lines = append(lines, layoutLine{})
+			}
+
+			current := &lines[len(lines)-1]
+			if i > 0 && len(current.tokens) == 0 {
+				trimmed := strings.TrimLeft(part, tab)
+				current.depth = len(part) - len(trimmed)
+				part = trimmed
+			}
+
+			if part != empty {
+				current.tokens = append(current.tokens, Token{Kind: TokenWhitespace, Value: part, Path: token.Path})
+			}
+		}
+	}
+	return lines
+}
+
+func tokensWidth(tokens []Token) int {
+	width := 0
+	for _, token := range tokens {
+		width += utf8.RuneCountInString(token.Value)
+	}
+	return width
+}
+
+func lastSpace(runes []rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == ' ' {
+			return i
+		}
+	}
+	return -1
+}
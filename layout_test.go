@@ -0,0 +1,145 @@
+package print
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrinterLayout(t *testing.T) {
+	data := map[string]any{
+		"name": "john",
+		"user": map[string]any{
+			"bio":  "a very long biography that does not fit",
+			"tags": []string{"a"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{
+			name: "line numbers",
+			opts: []Option{WithLineNumbers()},
+			want: ` 1 │ {
+ 2 │     "name": "john",
+ 3 │     "user": {
+ 4 │         "bio": "a very long biography that does not fit",
+ 5 │         "tags": [
+ 6 │             "a"
+ 7 │         ]
+ 8 │     }
+ 9 │ }
+`,
+		},
+		{
+			name: "depth guides",
+			opts: []Option{WithDepthGuides()},
+			want: `{
+│   "name": "john",
+│   "user": {
+│   │   "bio": "a very long biography that does not fit",
+│   │   "tags": [
+│   │   │   "a"
+│   │   ]
+│   }
+}
+`,
+		},
+		{
+			name: "wrap keeps indentation",
+			opts: []Option{WithWrap(40), WithLineNumbers()},
+			want: ` 1 │ {
+ 2 │     "name": "john",
+ 3 │     "user": {
+ 4 │         "bio": "a very long 
+   │             biography that does 
+   │             not fit",
+ 5 │         "tags": [
+ 6 │             "a"
+ 7 │         ]
+ 8 │     }
+ 9 │ }
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPrinter(append(tt.opts, WithColorMode(ColorNever))...)
+			got, err := p.HighlightJSON(data)
+			if err != nil {
+				t.Fatalf("HighlightJSON() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("HighlightJSON() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrinterLayoutHighlighted(t *testing.T) {
+	p := NewPrinter(
+		WithHighlighter(NewANSIHighlighter(Color16)),
+		WithColorMode(ColorAlways),
+		WithLineNumbers(),
+		WithWrap(30),
+	)
+
+	got := p.MaybeSecureHighlightJSON(map[string]string{"password": "secret", "note": strings.Repeat("x", 40)})
+
+	if !strings.Contains(got, ansiDim+"1 │ "+ansiReset) {
+		t.Errorf("line numbers should use the gutter colour, got %q", got)
+	}
+
+	if !strings.Contains(got, ansiDim+ansiRed+`"****"`+ansiReset) {
+		t.Errorf("masked values should keep their colour, got %q", got)
+	}
+
+	for _, line := range strings.Split(stripANSI(got), "\n") {
+		if len([]rune(line)) > 30 {
+			t.Errorf("line exceeds wrap width: %q", line)
+		}
+	}
+}
+
+func TestPrinterLayoutMaskGlyph(t *testing.T) {
+	long := MaskFunc(func(value string) (string, error) {
+		return strings.Repeat("masked ", 10), nil
+	})
+
+	p := NewPrinter(
+		WithHighlighter(NewANSIHighlighter(Color16)),
+		WithColorMode(ColorAlways),
+		WithMasker(NewKeyMasker(ExactKey("secret").WithStrategy(long))),
+		WithMaskGlyph("🔒"),
+		WithWrap(30),
+	)
+
+	got := stripANSI(p.MaybeSecureHighlightJSON(map[string]string{"secret": "s3cr3t"}))
+
+	if n := strings.Count(got, "🔒"); n != 1 {
+		t.Errorf("glyph should prefix the masked value once, found %d:\n%s", n, got)
+	}
+	if !strings.Contains(got, `"secret": 🔒 "masked`) {
+		t.Errorf("glyph should prefix the value, got:\n%s", got)
+	}
+	if lines := strings.Split(got, "\n"); len(lines) < 4 {
+		t.Errorf("masked value should be wrapped, got:\n%s", got)
+	}
+}
+
+func stripANSI(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\x1b' {
+			for i < len(s) && s[i] != 'm' {
+				i++
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,138 @@
+package print
+
+import (
+	"os"
+	"reflect"
+	"strings"
+)
+
+// Level classifies values by the audience allowed to read them
+type Level int
+
+const (
+	// LevelPublic values can be read by anyone
+	LevelPublic Level = iota
+	// LevelInternal values can be read by internal audiences,
+	// e.g. developers in local environments
+	LevelInternal
+	// LevelRestricted values can only be read by restricted audiences
+	LevelRestricted
+)
+
+// AudienceEnv is the env var read by AudienceFromEnv
+const AudienceEnv = "GOPRINT_AUDIENCE"
+
+func (l Level) String() string {
+	switch l {
+	case LevelInternal:
+		return "internal"
+	case LevelRestricted:
+		return "restricted"
+	default:
+		return "public"
+	}
+}
+
+// ParseLevel parses a level name: public, internal or restricted
+func ParseLevel(s string) (Level, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "public":
+		return LevelPublic, true
+	case "internal":
+		return LevelInternal, true
+	case "restricted":
+		return LevelRestricted, true
+	}
+	return LevelPublic, false
+}
+
+// AudienceFromEnv returns the audience set in GOPRINT_AUDIENCE,
+// unset or unknown values fall back to LevelPublic
+func AudienceFromEnv() Level {
+	level, _ := ParseLevel(os.Getenv(AudienceEnv))
+	return level
+}
+
+// LevelMasker is a Masker for an audience. Values classified above
+// the audience are masked, either struct fields tagged with their
+// level, e.g. `print:"internal"` or `print:"safe,internal"`, or
+// values matching key rules:
+//
+//	type User struct {
+//	    ID    string `json:"id"`
+//	    Email string `json:"email" print:"internal"`
+//	    SSN   string `json:"ssn" print:"restricted"`
+//	}
+//
+//	// production logs: email and ssn are masked
+//	print.PrintMasker = print.ChainMasker(print.PrintMasker, print.NewLevelMasker(print.LevelPublic))
+//
+// Other maskers in the chain still apply to every audience
+type LevelMasker struct {
+	audience Level
+	rules    map[Level][]KeyRule
+	strategy MaskStrategy
+}
+
+// NewLevelMasker creates a LevelMasker for audience,
+// masked values are replaced with "****"
+func NewLevelMasker(audience Level) *LevelMasker {
+	return &LevelMasker{
+		audience: audience,
+		rules:    make(map[Level][]KeyRule),
+		strategy: defaultStrategy,
+	}
+}
+
+// Classify sets the level of the values matching rules
+func (m *LevelMasker) Classify(level Level, rules ...KeyRule) *LevelMasker {
+	m.rules[level] = append(m.rules[level], rules...)
+	return m
+}
+
+// WithStrategy sets the strategy used to mask values
+func (m *LevelMasker) WithStrategy(s MaskStrategy) *LevelMasker {
+	m.strategy = s
+	return m
+}
+
+// Mask returns a copy of target with the values
+// the audience is not allowed to read masked
+func (m *LevelMasker) Mask(target any) (any, error) {
+	return m.maskReport(target, nil)
+}
+
+func (m *LevelMasker) maskReport(target any, hit func(path, rule string)) (any, error) {
+	if target == nil {
+		return nil, nil
+	}
+
+	w := maskWalker{rule: m.rule, field: m.field, hit: hit}
+	out, err := w.child(rootPath, reflect.ValueOf(target))
+	if err != nil {
+		return nil, err
+	}
+	return out.Interface(), nil
+}
+
+func (m *LevelMasker) rule(path string, keys ...string) (KeyRule, bool) {
+	for level := LevelRestricted; level > m.audience; level-- {
+		for _, rule := range m.rules[level] {
+			if rule.matchesAt(path, keys...) {
+				return rule.WithStrategy(m.strategy), true
+			}
+		}
+	}
+	return KeyRule{}, false
+}
+
+// field masks struct fields tagged with a level above the
+// audience, other print tag values such as safe are ignored
+func (m *LevelMasker) field(f reflect.StructField) (string, MaskStrategy, bool) {
+	for _, value := range printTags(f) {
+		if level, ok := ParseLevel(value); ok && level > m.audience {
+			return "level:" + level.String(), m.strategy, true
+		}
+	}
+	return empty, nil, false
+}
@@ -0,0 +1,197 @@
+package print
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+type levelUser struct {
+	ID       string            `json:"id"`
+	Email    string            `json:"email" print:"internal"`
+	SSN      string            `json:"ssn" print:"restricted"`
+	Age      int               `json:"age" print:"internal"`
+	Password string            `json:"password"`
+	Meta     map[string]string `json:"meta"`
+}
+
+func TestLevelMasker(t *testing.T) {
+	original := PrintMasker
+	defer func() { PrintMasker = original }()
+
+	user := levelUser{
+		ID:       "usr_1",
+		Email:    "jane@example.com",
+		SSN:      "123-45-6789",
+		Age:      42,
+		Password: "hunter2",
+		Meta:     map[string]string{"ip": "10.0.0.1", "plan": "pro"},
+	}
+
+	tests := []struct {
+		audience Level
+		want     []string
+	}{
+		{
+			audience: LevelPublic,
+			want:     []string{`"email":"****"`, `"ssn":"****"`, `"age":0`, `"ip":"****"`},
+		},
+		{
+			audience: LevelInternal,
+			want:     []string{`"email":"jane@example.com"`, `"ssn":"****"`, `"age":42`, `"ip":"10.0.0.1"`},
+		},
+		{
+			audience: LevelRestricted,
+			want:     []string{`"email":"jane@example.com"`, `"ssn":"123-45-6789"`, `"age":42`, `"ip":"10.0.0.1"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.audience.String(), func(t *testing.T) {
+			PrintMasker = ChainMasker(original, NewLevelMasker(tt.audience).Classify(LevelInternal, ExactKey("ip")))
+
+			got, err := SecureCompactJSON(user)
+			if err != nil {
+				t.Fatalf("SecureCompactJSON() error = %v", err)
+			}
+
+			// the default masker applies to every audience
+			want := append(tt.want, `"id":"usr_1"`, `"password":"****"`, `"plan":"pro"`)
+			for _, w := range want {
+				if !strings.Contains(got, w) {
+					t.Errorf("SecureCompactJSON() = %s, want %s", got, w)
+				}
+			}
+		})
+	}
+}
+
+func TestLevelMaskerReport(t *testing.T) {
+	original := PrintMasker
+	defer func() { PrintMasker = original }()
+
+	PrintMasker = NewLevelMasker(LevelPublic).Classify(LevelRestricted, FoldKey("token"))
+
+	_, report, err := SecureJSONWithReport(map[string]any{
+		"user":  levelUser{ID: "usr_1", Email: "jane@example.com"},
+		"Token": "abc",
+	})
+	if err != nil {
+		t.Fatalf("SecureJSONWithReport() error = %v", err)
+	}
+
+	for path, rule := range map[string]string{
+		"$.user.email": "level:internal",
+		"$.Token":      "fold:token",
+	} {
+		entry, ok := report.Entry(path)
+		if !ok || entry.Rule != rule {
+			t.Errorf("Entry(%s) = %+v, want rule %s", path, entry, rule)
+		}
+	}
+}
+
+func TestLevelMaskerSafeTag(t *testing.T) {
+	type account struct {
+		ID    string `json:"id" print:"safe,internal"`
+		Email string `json:"email" print:"internal"`
+	}
+
+	value := account{ID: "acc_1", Email: "jane@example.com"}
+
+	got, err := NewLevelMasker(LevelPublic).Mask(value)
+	if err != nil {
+		t.Fatalf("Mask() error = %v", err)
+	}
+	if out := got.(account); out.ID == value.ID || out.Email == value.Email {
+		t.Errorf("LevelMasker.Mask() = %+v, want id and email masked", out)
+	}
+
+	got, err = NewAllowMasker().Mask(value)
+	if err != nil {
+		t.Fatalf("Mask() error = %v", err)
+	}
+	if out := got.(account); out.ID != value.ID || out.Email == value.Email {
+		t.Errorf("AllowMasker.Mask() = %+v, want id kept and email masked", out)
+	}
+}
+
+func TestPrinterWithAudience(t *testing.T) {
+	user := levelUser{ID: "usr_1", Email: "jane@example.com", SSN: "123-45-6789", Password: "hunter2"}
+
+	tests := []struct {
+		audience Level
+		want     []string
+		masked   []string
+	}{
+		{LevelPublic, nil, []string{"jane@example.com", "123-45-6789", "hunter2"}},
+		{LevelInternal, []string{"jane@example.com"}, []string{"123-45-6789", "hunter2"}},
+		{LevelRestricted, []string{"jane@example.com", "123-45-6789"}, []string{"hunter2"}},
+	}
+
+	var wg sync.WaitGroup
+	for _, tt := range tests {
+		p := NewPrinter(WithColorMode(ColorNever), WithAudience(tt.audience))
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 20 {
+				got, err := p.SecureHighlightJSON(user)
+				if err != nil {
+					t.Errorf("SecureHighlightJSON(%s) error = %v", tt.audience, err)
+					return
+				}
+				for _, want := range tt.want {
+					if !strings.Contains(got, want) {
+						t.Errorf("SecureHighlightJSON(%s) = %s, want %s", tt.audience, got, want)
+					}
+				}
+				for _, secret := range tt.masked {
+					if strings.Contains(got, secret) {
+						t.Errorf("SecureHighlightJSON(%s) leaked %s:\n%s", tt.audience, secret, got)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	custom := NewPrinter(WithColorMode(ColorNever), WithMasker(NewKeyMasker(ExactKey("id"))))
+	got, err := custom.SecureHTMLJSON(user)
+	if err != nil {
+		t.Fatalf("SecureHTMLJSON() error = %v", err)
+	}
+	if strings.Contains(got, "usr_1") || !strings.Contains(got, "hunter2") {
+		t.Errorf("SecureHTMLJSON() should only use the printer masker, got %s", got)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Level
+		ok    bool
+	}{
+		{"public", LevelPublic, true},
+		{" Internal ", LevelInternal, true},
+		{"RESTRICTED", LevelRestricted, true},
+		{"safe", LevelPublic, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseLevel(tt.input)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseLevel(%q) = %v, %v, want %v, %v", tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	t.Setenv(AudienceEnv, "internal")
+	if got := AudienceFromEnv(); got != LevelInternal {
+		t.Errorf("AudienceFromEnv() = %v, want internal", got)
+	}
+	t.Setenv(AudienceEnv, "admin")
+	if got := AudienceFromEnv(); got != LevelPublic {
+		t.Errorf("AudienceFromEnv() = %v, want public", got)
+	}
+}
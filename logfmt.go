@@ -0,0 +1,121 @@
+package print
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ArrayNotation controls how array indexes are written
+// in flattened keys
+type ArrayNotation int
+
+const (
+	// ArrayDot writes indexes as path segments, e.g. items.0.id
+	ArrayDot ArrayNotation = iota
+	// ArrayBrackets writes indexes in brackets, e.g. items[0].id
+	ArrayBrackets
+)
+
+// LogfmtOption configures the output of Logfmt
+type LogfmtOption func(*flattener)
+
+// WithKeySeparator sets the separator used to join
+// nested keys, defaults to "."
+func WithKeySeparator(separator string) LogfmtOption {
+	return func(f *flattener) {
+		f.separator = separator
+	}
+}
+
+// WithArrayNotation sets how array indexes are written
+func WithArrayNotation(notation ArrayNotation) LogfmtOption {
+	return func(f *flattener) {
+		f.brackets = notation == ArrayBrackets
+	}
+}
+
+// Logfmt will flatten data into dot-path keys and print it
+// as logfmt, e.g. user.address.city=Berlin
+func Logfmt(data any, opts ...LogfmtOption) (string, error) {
+	f := defaultFlattener
+	for _, opt := range opts {
+		opt(&f)
+	}
+
+	fields := f.flatten(empty, safeToJSON(data), nil)
+
+	var b strings.Builder
+	for i, field := range fields {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+
+		key := field.key
+		if key == empty {
+			key = valueColumn
+		}
+
+		b.WriteString(logfmtKey(key))
+		b.WriteByte('=')
+		b.WriteString(logfmtValue(field.value))
+	}
+
+	return b.String(), nil
+}
+
+// MaybeLogfmt will return a logfmt string, in case of an
+// error it will return the message: error printing
+func MaybeLogfmt(data any, opts ...LogfmtOption) string {
+	out, err := Logfmt(data, opts...)
+	if err != nil {
+		return fmt.Sprintf("error printing: %s", err)
+	}
+	return out
+}
+
+// SecureLogfmt will mask sensitive data before
+// printing it as logfmt
+func SecureLogfmt(data any, opts ...LogfmtOption) (string, error) {
+	_, tree, err := maskTree(data)
+	if err != nil {
+		return empty, err
+	}
+	return Logfmt(tree, opts...)
+}
+
+// MaybeSecureLogfmt will return a masked logfmt string, in case
+// of an error it will return the message: error printing
+func MaybeSecureLogfmt(data any, opts ...LogfmtOption) string {
+	out, err := SecureLogfmt(data, opts...)
+	if err != nil {
+		return fmt.Sprintf("error printing: %s", err)
+	}
+	return out
+}
+
+// logfmtKey replaces characters that are not valid in a key
+func logfmtKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || unicode.IsControl(r) {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+func logfmtValue(v any) string {
+	if v == nil {
+		return "null"
+	}
+
+	out := cellString(v)
+	if out == empty || strings.IndexFunc(out, needsQuote) != -1 {
+		return fmt.Sprintf("%q", out)
+	}
+	return out
+}
+
+func needsQuote(r rune) bool {
+	return r <= ' ' || r == '=' || r == '"' || r == '\\' || unicode.IsControl(r) || r == unicode.ReplacementChar
+}
@@ -0,0 +1,73 @@
+package print
+
+import "testing"
+
+func TestLogfmt(t *testing.T) {
+	type address struct {
+		City   string `json:"city"`
+		Street string `json:"street"`
+	}
+
+	type user struct {
+		Name     string   `json:"name"`
+		Password string   `json:"password"`
+		Address  address  `json:"address"`
+		Roles    []string `json:"roles"`
+		Manager  *user    `json:"manager"`
+	}
+
+	input := user{
+		Name:     "john",
+		Password: "secret123",
+		Address:  address{City: "Berlin", Street: `Main "St" 1`},
+		Roles:    []string{"admin", "dev"},
+	}
+
+	tests := []struct {
+		name   string
+		input  any
+		opts   []LogfmtOption
+		secure bool
+		want   string
+	}{
+		{
+			name:  "flattens nested values",
+			input: input,
+			want:  `address.city=Berlin address.street="Main \"St\" 1" manager=null name=john password=secret123 roles.0=admin roles.1=dev`,
+		},
+		{
+			name:  "custom separator and brackets",
+			input: input,
+			opts:  []LogfmtOption{WithKeySeparator("_"), WithArrayNotation(ArrayBrackets)},
+			want:  `address_city=Berlin address_street="Main \"St\" 1" manager=null name=john password=secret123 roles[0]=admin roles[1]=dev`,
+		},
+		{
+			name:   "masks sensitive data",
+			input:  map[string]any{"password": "secret", "msg": "", "user id": 1},
+			secure: true,
+			want:   `msg="" password=**** user_id=1`,
+		},
+		{
+			name:  "scalar value",
+			input: "hello world",
+			want:  `value="hello world"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			render := Logfmt
+			if tt.secure {
+				render = SecureLogfmt
+			}
+
+			got, err := render(tt.input, tt.opts...)
+			if err != nil {
+				t.Fatalf("Logfmt() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Logfmt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package print
+
+import "fmt"
+
+// maskedPaths returns the paths of the values that
+// changed between the original and the masked tree
+func maskedPaths(original, masked any) map[string]bool {
+	d := &differ{config: &diffConfig{}}
+	d.compare(rootPath, original, masked)
+
+	paths := make(map[string]bool, len(d.changes))
+	for _, change := range d.changes {
+		paths[change.Path] = true
+	}
+	return paths
+}
+
+// maskTree masks data using PrintMasker and normalizes it. The
+// normalized tree is masked again, so secrets exposed through
+// String or MarshalJSON are caught too. Values masked by the
+// first pass are kept as they are. It returns the normalized
+// original and masked trees
+func maskTree(data any) (any, any, error) {
+	return maskTreeWith(PrintMasker, data, nil)
+}
+
+// maskTreeWith works like maskTree masking data with m, hit is
+// called with the path and rule of the values masked by
+// reporting maskers
+func maskTreeWith(m Masker, data any, hit func(path, rule string)) (any, any, error) {
+	if data == nil {
+		// nothing to mask, e.g. the missing side of a diff
+		return nil, nil, nil
+	}
+
+	maskedData, err := maskWith(m, data, hit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error masking data: %w", err)
+	}
+
+	original, err := jsonTree(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error printing data: %w", err)
+	}
+
+	tree, err := jsonTree(maskedData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error printing data: %w", err)
+	}
+
+	second, err := maskNormalized(m, data, tree, maskedPaths(original, tree), hit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error masking data: %w", err)
+	}
+
+	if second, err = jsonTree(second); err != nil {
+		return nil, nil, fmt.Errorf("error printing data: %w", err)
+	}
+
+	return original, second, nil
+}
+
+// treeMasker is implemented by maskers that handle the normalized
+// tree pass themselves. data is the value given to the first pass
+// and masked holds the paths of the values it masked
+type treeMasker interface {
+	maskTree(data, tree any, masked map[string]bool, hit func(path, rule string)) (any, error)
+}
+
+// maskNormalized masks a normalized tree with m, values
+// at masked paths are kept as they are
+func maskNormalized(m Masker, data, tree any, masked map[string]bool, hit func(path, rule string)) (any, error) {
+	if t, ok := m.(treeMasker); ok {
+		return t.maskTree(data, tree, masked, hit)
+	}
+
+	out, err := maskWith(m, tree, hit)
+	if err != nil {
+		return nil, err
+	}
+
+	if out, err = jsonTree(out); err != nil {
+		return nil, err
+	}
+	return keepMasked(rootPath, tree, out, masked), nil
+}
+
+func (c maskerChain) maskTree(data, tree any, masked map[string]bool, hit func(path, rule string)) (any, error) {
+	var err error
+	for _, m := range c {
+		if tree, err = maskNormalized(m, data, tree, masked, hit); err != nil {
+			return nil, err
+		}
+	}
+	return tree, nil
+}
+
+// keepMasked returns second, restoring the values found at
+// masked paths from first so they are not masked twice
+func keepMasked(path string, first, second any, masked map[string]bool) any {
+	if masked[path] {
+		return first
+	}
+
+	switch f := first.(type) {
+	case map[string]any:
+		s, ok := second.(map[string]any)
+		if !ok {
+			return second
+		}
+		out := make(map[string]any, len(s))
+		for key, value := range s {
+			if prev, ok := f[key]; ok {
+				value = keepMasked(keyPath(path, key), prev, value, masked)
+			}
+			out[key] = value
+		}
+		return out
+
+	case []any:
+		s, ok := second.([]any)
+		if !ok || len(s) != len(f) {
+			return second
+		}
+		out := make([]any, len(s))
+		for i := range s {
+			out[i] = keepMasked(indexPath(path, i), f[i], s[i], masked)
+		}
+		return out
+
+	default:
+		return second
+	}
+}
+
+// secureTree masks data with m and returns the normalized masked
+// tree together with the paths of the values that were masked
+func secureTree(m Masker, data any) (any, map[string]bool, error) {
+	original, tree, err := maskTreeWith(m, data, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tree, maskedPaths(original, tree), nil
+}
+
+// secureTokens tokenizes data masked with m,
+// flagging the values that were masked
+func secureTokens(m Masker, data any) ([]Token, error) {
+	tree, masked, err := secureTree(m, data)
+	if err != nil {
+		return nil, err
+	}
+	return jsonTokens(tree, masked), nil
+}
@@ -0,0 +1,125 @@
+package print
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type dsnConfig struct {
+	Host     string
+	User     string
+	Password string
+}
+
+func (c dsnConfig) String() string {
+	return fmt.Sprintf("postgres://%s:%s@%s/app", c.User, c.Password, c.Host)
+}
+
+type credentials struct {
+	user, password string
+}
+
+func (c credentials) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"user": c.user, "password": c.password})
+}
+
+func TestSecureJSONMasksNormalizedTree(t *testing.T) {
+	original := PrintMasker
+	defer func() { PrintMasker = original }()
+
+	PrintMasker = ChainMasker(PrintMasker, NewDetectorMasker(URLCredentialsDetector))
+
+	data := map[string]any{
+		"database":    dsnConfig{Host: "db", User: "app", Password: "hunter2"},
+		"credentials": credentials{user: "admin", password: "s3cr3t"},
+	}
+
+	got, err := SecureJSON(data)
+	if err != nil {
+		t.Fatalf("SecureJSON() error = %v", err)
+	}
+
+	for _, secret := range []string{"hunter2", "s3cr3t"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("SecureJSON() leaked %q:\n%s", secret, got)
+		}
+	}
+
+	for _, want := range []string{
+		`"database": "postgres://app:****@db/app"`,
+		`"password": "****"`,
+		`"user": "admin"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("SecureJSON() missing %s:\n%s", want, got)
+		}
+	}
+}
+
+func TestSecureJSONMasksOnce(t *testing.T) {
+	original := PrintMasker
+	defer func() { PrintMasker = original }()
+
+	length := MaskFunc(func(value string) (string, error) {
+		return fmt.Sprintf("[%d]", len(value)), nil
+	})
+	PrintMasker = NewKeyMasker(ExactKey("secret").WithStrategy(length))
+
+	got, err := SecureCompactJSON(map[string]any{
+		"secret": "s3cr3t",
+		"nested": []any{map[string]any{"secret": "hunter22"}},
+	})
+	if err != nil {
+		t.Fatalf("SecureCompactJSON() error = %v", err)
+	}
+
+	want := `{"nested":[{"secret":"[8]"}],"secret":"[6]"}`
+	if got != want {
+		t.Errorf("SecureCompactJSON() = %s, want %s", got, want)
+	}
+}
+
+func TestSecureNil(t *testing.T) {
+	data := map[string]any{"id": 1, "password": "hunter2"}
+
+	tests := []struct {
+		name string
+		call func() (any, error)
+		want string
+	}{
+		{"Diff added", func() (any, error) { r, err := Diff(nil, data); return r, err }, `"password": "****"`},
+		{"Diff removed", func() (any, error) { r, err := Diff(data, nil); return r, err }, `"password": "****"`},
+		{"SecureJSONPatch", func() (any, error) { return SecureJSONPatch(data, nil) }, `"value":null`},
+		{"SecureMergePatch", func() (any, error) { return SecureMergePatch(nil, data) }, `"password":"****"`},
+		{"WriteSecureNDJSON", func() (any, error) {
+			var b bytes.Buffer
+			err := WriteSecureNDJSON(&b, []any{nil, data})
+			return b.String(), err
+		}, "null\n"},
+		{"SecureLogfmt", func() (any, error) { return SecureLogfmt(nil) }, "value=null"},
+		{"SecureHighlightJSON", func() (any, error) { return SecureHighlightJSON(nil) }, "null"},
+		{"SecureHTMLJSON", func() (any, error) { return SecureHTMLJSON(nil) }, "null"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.call()
+			if err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+
+			out := fmt.Sprint(got)
+			if r, ok := got.(*DiffResult); ok {
+				out = r.Unified()
+			} else if _, ok := got.(string); !ok {
+				out = MaybeCompactJSON(got)
+			}
+			if !strings.Contains(out, tt.want) {
+				t.Errorf("%s() = %s, want %s", tt.name, out, tt.want)
+			}
+		})
+	}
+}
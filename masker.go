@@ -1,8 +1,20 @@
 package print
 
-import "github.com/goliatone/go-masker"
+import (
+	"fmt"
+	"reflect"
+	"sync"
 
-var PrintMasker Masker = defaultMasker{masker: *masker.Default}
+	"github.com/goliatone/go-masker"
+)
+
+var PrintMasker Masker = newDefaultMasker()
+
+// newDefaultMasker returns the masker PrintMasker starts with,
+// masking the default field names and struct tags
+func newDefaultMasker() Masker {
+	return defaultMasker{masker: *masker.Default}
+}
 
 // Masker defines the interface for masking data
 type Masker interface {
@@ -16,6 +28,116 @@ type defaultMasker struct {
 	masker masker.Masker
 }
 
+// defaultMaskerMu serializes the default maskers, go-mask reuses
+// a cached value per struct type while masking it
+var defaultMaskerMu sync.Mutex
+
 func (d defaultMasker) Mask(target any) (ret any, err error) {
+	defaultMaskerMu.Lock()
+	defer defaultMaskerMu.Unlock()
 	return d.masker.Mask(target)
 }
+
+// ChainMasker returns a Masker applying maskers in
+// order, each one masks the output of the previous
+func ChainMasker(maskers ...Masker) Masker {
+	return maskerChain(maskers)
+}
+
+type maskerChain []Masker
+
+func (c maskerChain) Mask(target any) (any, error) {
+	var err error
+	for _, m := range c {
+		if target, err = m.Mask(target); err != nil {
+			return nil, err
+		}
+	}
+	return target, nil
+}
+
+// exceptMasker masks with masker and restores the values
+// matched by skip, so maskers without exceptions honour them
+type exceptMasker struct {
+	masker Masker
+	skip   func(path string, keys ...string) bool
+}
+
+func (m exceptMasker) Mask(target any) (any, error) {
+	out, err := m.masker.Mask(target)
+	if err != nil || target == nil || out == nil {
+		return out, err
+	}
+	return m.restore(rootPath, reflect.ValueOf(target), reflect.ValueOf(out)).Interface(), nil
+}
+
+// restore returns a copy of masked with the values at
+// skipped paths taken from original
+func (m exceptMasker) restore(path string, original, masked reflect.Value, keys ...string) reflect.Value {
+	if !original.IsValid() || !masked.IsValid() || original.Type() != masked.Type() {
+		return masked
+	}
+
+	if m.skip(path, keys...) {
+		return original
+	}
+
+	switch masked.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if original.IsNil() || masked.IsNil() {
+			return masked
+		}
+		elem := m.restore(path, original.Elem(), masked.Elem())
+		if masked.Kind() == reflect.Interface {
+			return wrapValue(masked.Type(), elem)
+		}
+		out := reflect.New(masked.Type().Elem())
+		out.Elem().Set(elem)
+		return out
+
+	case reflect.Map:
+		if original.IsNil() || masked.IsNil() {
+			return masked
+		}
+		out := reflect.MakeMapWithSize(masked.Type(), masked.Len())
+		for _, key := range masked.MapKeys() {
+			name := fmt.Sprintf("%v", key.Interface())
+			out.SetMapIndex(key, m.restore(keyPath(path, name), original.MapIndex(key), masked.MapIndex(key), name))
+		}
+		return out
+
+	case reflect.Slice, reflect.Array:
+		if masked.Kind() == reflect.Slice && (original.IsNil() || masked.IsNil()) {
+			return masked
+		}
+		if original.Len() != masked.Len() {
+			return masked
+		}
+		out := reflect.New(masked.Type()).Elem()
+		if masked.Kind() == reflect.Slice {
+			out = reflect.MakeSlice(masked.Type(), masked.Len(), masked.Len())
+		}
+		for i := range masked.Len() {
+			out.Index(i).Set(m.restore(indexPath(path, i), original.Index(i), masked.Index(i)))
+		}
+		return out
+
+	case reflect.Struct:
+		out := reflect.New(masked.Type()).Elem()
+		out.Set(masked)
+
+		t := masked.Type()
+		for i := range t.NumField() {
+			field := t.Field(i)
+			name, _, ok := jsonFieldName(field)
+			if !ok {
+				continue
+			}
+			out.Field(i).Set(m.restore(keyPath(path, name), original.Field(i), masked.Field(i), name, field.Name))
+		}
+		return out
+
+	default:
+		return masked
+	}
+}
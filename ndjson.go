@@ -0,0 +1,97 @@
+package print
+
+import (
+	"errors"
+	"io"
+	"reflect"
+)
+
+var errNDJSONInput = errors.New("NDJSON input must be a slice, array, channel or iterator")
+
+// WriteNDJSON will write each record in data as a single line
+// of JSON. Data can be a slice, an array, a channel or an
+// iterator such as iter.Seq[T]. Channels are read until closed,
+// after an error the remaining records are discarded in the
+// background so the producer is not blocked
+func WriteNDJSON(w io.Writer, data any) error {
+	return writeNDJSON(w, data, func(record any) (any, error) {
+		return record, nil
+	})
+}
+
+// WriteSecureNDJSON will mask each record before writing
+// it as a single line of JSON
+func WriteSecureNDJSON(w io.Writer, data any) error {
+	return writeNDJSON(w, data, func(record any) (any, error) {
+		_, tree, err := maskTree(record)
+		return tree, err
+	})
+}
+
+func writeNDJSON(w io.Writer, data any, prepare func(any) (any, error)) error {
+	var err error
+	write := func(record any) bool {
+		if record, err = prepare(record); err != nil {
+			return false
+		}
+		err = encodeJSON(w, record, empty)
+		return err == nil
+	}
+
+	val := reflect.ValueOf(data)
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := range val.Len() {
+			if !write(val.Index(i).Interface()) {
+				break
+			}
+		}
+	case reflect.Chan, reflect.Func:
+		if val.IsNil() || !isSeq(val.Type()) {
+			return errNDJSONInput
+		}
+		for item := range val.Seq() {
+			if !write(item.Interface()) {
+				if val.Kind() == reflect.Chan {
+					go drain(val)
+				}
+				break
+			}
+		}
+	default:
+		return errNDJSONInput
+	}
+
+	return err
+}
+
+// drain receives from ch until it is closed
+func drain(ch reflect.Value) {
+	for {
+		if _, ok := ch.Recv(); !ok {
+			return
+		}
+	}
+}
+
+// isSeq reports if t can be iterated using reflect.Value.Seq,
+// i.e. a receive channel or a func(yield func(T) bool)
+func isSeq(t reflect.Type) bool {
+	if t.Kind() == reflect.Chan {
+		return t.ChanDir()&reflect.RecvDir != 0
+	}
+
+	if t.NumIn() != 1 || t.NumOut() != 0 {
+		return false
+	}
+
+	yield := t.In(0)
+	return yield.Kind() == reflect.Func &&
+		yield.NumIn() == 1 &&
+		yield.NumOut() == 1 &&
+		yield.Out(0).Kind() == reflect.Bool
+}
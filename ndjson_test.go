@@ -0,0 +1,131 @@
+package print
+
+import (
+	"bytes"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+)
+
+func TestWriteNDJSON(t *testing.T) {
+	users := []tableUser{
+		{ID: 1, Name: "john", Password: "secret123"},
+		{ID: 2, Name: "jane", Password: "hunter2"},
+	}
+
+	var seq iter.Seq[map[string]int] = func(yield func(map[string]int) bool) {
+		for i := range 3 {
+			if !yield(map[string]int{"n": i}) {
+				return
+			}
+		}
+	}
+
+	ch := make(chan string, 2)
+	ch <- "a"
+	ch <- "b"
+	close(ch)
+
+	tests := []struct {
+		name    string
+		input   any
+		secure  bool
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "slice",
+			input: users,
+			want: `{"id":1,"name":"john","password":"secret123"}
+{"id":2,"name":"jane","password":"hunter2"}
+`,
+		},
+		{
+			name:   "secure slice",
+			input:  users,
+			secure: true,
+			want: `{"id":1,"name":"john","password":"****"}
+{"id":2,"name":"jane","password":"****"}
+`,
+		},
+		{
+			name:  "iterator",
+			input: seq,
+			want:  "{\"n\":0}\n{\"n\":1}\n{\"n\":2}\n",
+		},
+		{
+			name:  "channel",
+			input: ch,
+			want:  "\"a\"\n\"b\"\n",
+		},
+		{
+			name:    "unsupported input",
+			input:   map[string]string{"a": "b"},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported func",
+			input:   func() {},
+			wantErr: true,
+		},
+		{
+			name:    "nil iterator",
+			input:   iter.Seq[int](nil),
+			wantErr: true,
+		},
+		{
+			name:    "nil channel",
+			input:   (chan int)(nil),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+			write := WriteNDJSON
+			if tt.secure {
+				write = WriteSecureNDJSON
+			}
+
+			err := write(buffer, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WriteNDJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := buffer.String(); !tt.wantErr && got != tt.want {
+				t.Errorf("WriteNDJSON() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteNDJSONErrorDrainsChannel(t *testing.T) {
+	ch := make(chan any)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer close(ch)
+		ch <- "first"
+		// blocks forever unless the channel is drained
+		ch <- "next"
+		ch <- "last"
+	}()
+
+	if err := WriteNDJSON(failingWriter{}, ch); err == nil {
+		t.Fatal("WriteNDJSON() error = nil, want a write error")
+	}
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("WriteNDJSON() left the producer blocked")
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
@@ -0,0 +1,227 @@
+package print
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSON Patch operations, see RFC 6902
+const (
+	PatchAdd     = "add"
+	PatchRemove  = "remove"
+	PatchReplace = "replace"
+)
+
+var pointerReplacer = strings.NewReplacer("~", "~0", "/", "~1")
+
+// PatchOperation is a single RFC 6902 JSON Patch operation
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value,omitempty"`
+}
+
+// MarshalJSON will always include the value of add and
+// replace operations, even when the value is null
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == PatchRemove {
+		return json.Marshal(map[string]any{"op": o.Op, "path": o.Path})
+	}
+	return json.Marshal(map[string]any{"op": o.Op, "path": o.Path, "value": o.Value})
+}
+
+// JSONPatch generates the RFC 6902 JSON Patch that
+// transforms a into b. Both values are normalized
+// before comparing them
+func JSONPatch(a, b any) ([]PatchOperation, error) {
+	before, after, masked, err := patchTrees(a, b, false)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make([]PatchOperation, 0)
+	return jsonPatch(empty, before, after, masked, ops), nil
+}
+
+// SecureJSONPatch generates the JSON Patch from the unmasked
+// values and masks sensitive data in the operation values, so
+// a changed secret still shows up as a masked replace
+func SecureJSONPatch(a, b any) ([]PatchOperation, error) {
+	before, after, masked, err := patchTrees(a, b, true)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make([]PatchOperation, 0)
+	return jsonPatch(empty, before, after, masked, ops), nil
+}
+
+// MergePatch generates the RFC 7386 JSON Merge Patch
+// that transforms a into b
+func MergePatch(a, b any) (any, error) {
+	before, after, masked, err := patchTrees(a, b, false)
+	if err != nil {
+		return nil, err
+	}
+	return mergePatch(before, after, masked), nil
+}
+
+// SecureMergePatch generates the JSON Merge Patch from the
+// unmasked values and masks sensitive data in the patch
+func SecureMergePatch(a, b any) (any, error) {
+	before, after, masked, err := patchTrees(a, b, true)
+	if err != nil {
+		return nil, err
+	}
+	return mergePatch(before, after, masked), nil
+}
+
+// patchTrees returns the normalized trees of a and b and the
+// tree the patch values are taken from, b masked when secure
+func patchTrees(a, b any, secure bool) (any, any, any, error) {
+	before, err := jsonTree(a)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error printing data: %w", err)
+	}
+
+	if secure {
+		after, masked, err := maskTree(b)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return before, after, masked, nil
+	}
+
+	after, err := jsonTree(b)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error printing data: %w", err)
+	}
+	return before, after, after, nil
+}
+
+// jsonPatch compares a and b, masked mirrors b and
+// holds the values written to the operations
+func jsonPatch(path string, a, b, masked any, ops []PatchOperation) []PatchOperation {
+	switch av := a.(type) {
+	case map[string]any:
+		if bv, ok := b.(map[string]any); ok {
+			mv, _ := masked.(map[string]any)
+			return patchMaps(path, av, bv, mv, ops)
+		}
+	case []any:
+		if bv, ok := b.([]any); ok {
+			mv, _ := masked.([]any)
+			return patchSlices(path, av, bv, mv, ops)
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		ops = append(ops, PatchOperation{Op: PatchReplace, Path: path, Value: masked})
+	}
+	return ops
+}
+
+func patchMaps(path string, a, b, masked map[string]any, ops []PatchOperation) []PatchOperation {
+	for _, key := range sortedKeys(a) {
+		if _, ok := b[key]; !ok {
+			ops = append(ops, PatchOperation{Op: PatchRemove, Path: pointer(path, key)})
+		}
+	}
+
+	for _, key := range sortedKeys(b) {
+		av, ok := a[key]
+		if !ok {
+			ops = append(ops, PatchOperation{Op: PatchAdd, Path: pointer(path, key), Value: masked[key]})
+			continue
+		}
+		ops = jsonPatch(pointer(path, key), av, b[key], masked[key], ops)
+	}
+	return ops
+}
+
+func patchSlices(path string, a, b, masked []any, ops []PatchOperation) []PatchOperation {
+	common := min(len(a), len(b))
+	for i := range common {
+		ops = jsonPatch(pointer(path, strconv.Itoa(i)), a[i], b[i], sliceItem(masked, i), ops)
+	}
+
+	// remove from the end so indexes stay valid
+	for i := len(a) - 1; i >= common; i-- {
+		ops = append(ops, PatchOperation{Op: PatchRemove, Path: pointer(path, strconv.Itoa(i))})
+	}
+
+	for i := common; i < len(b); i++ {
+		ops = append(ops, PatchOperation{Op: PatchAdd, Path: pointer(path, strconv.Itoa(i)), Value: sliceItem(masked, i)})
+	}
+	return ops
+}
+
+// sliceItem returns items[i] or nil when out of range
+func sliceItem(items []any, i int) any {
+	if i < len(items) {
+		return items[i]
+	}
+	return nil
+}
+
+// mergePatch compares a and b, masked mirrors b and
+// holds the values written to the patch
+func mergePatch(a, b, masked any) any {
+	bv, ok := b.(map[string]any)
+	if !ok {
+		return masked
+	}
+	mv, _ := masked.(map[string]any)
+
+	av, ok := a.(map[string]any)
+	if !ok {
+		av = map[string]any{}
+	}
+
+	patch := make(map[string]any)
+	for key := range av {
+		if _, ok := bv[key]; !ok {
+			patch[key] = nil
+		}
+	}
+
+	for key, value := range bv {
+		old, ok := av[key]
+		if !ok {
+			patch[key] = mv[key]
+			continue
+		}
+
+		if reflect.DeepEqual(old, value) {
+			continue
+		}
+
+		_, oldIsObject := old.(map[string]any)
+		if _, isObject := value.(map[string]any); isObject && oldIsObject {
+			patch[key] = mergePatch(old, value, mv[key])
+			continue
+		}
+
+		patch[key] = mv[key]
+	}
+	return patch
+}
+
+// pointer appends an escaped reference token to
+// a RFC 6901 JSON Pointer
+func pointer(path, token string) string {
+	return path + "/" + pointerReplacer.Replace(token)
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
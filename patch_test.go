@@ -0,0 +1,107 @@
+package print
+
+import (
+	"testing"
+)
+
+func TestJSONPatch(t *testing.T) {
+	before := map[string]any{
+		"name":     "john",
+		"password": "secret",
+		"a/b":      1,
+		"tags":     []string{"a", "b", "c"},
+		"address":  map[string]any{"city": "Berlin", "zip": "10115"},
+	}
+
+	after := map[string]any{
+		"name":     "john",
+		"password": "changed",
+		"tags":     []string{"a", "x"},
+		"address":  map[string]any{"city": "Paris", "zip": nil},
+		"admin":    false,
+	}
+
+	tests := []struct {
+		name  string
+		patch func(a, b any) ([]PatchOperation, error)
+		want  string
+	}{
+		{
+			name:  "json patch",
+			patch: JSONPatch,
+			want:  `[{"op":"remove","path":"/a~1b"},{"op":"replace","path":"/address/city","value":"Paris"},{"op":"replace","path":"/address/zip","value":null},{"op":"add","path":"/admin","value":false},{"op":"replace","path":"/password","value":"changed"},{"op":"replace","path":"/tags/1","value":"x"},{"op":"remove","path":"/tags/2"}]`,
+		},
+		{
+			name:  "secure json patch",
+			patch: SecureJSONPatch,
+			want:  `[{"op":"remove","path":"/a~1b"},{"op":"replace","path":"/address/city","value":"Paris"},{"op":"replace","path":"/address/zip","value":null},{"op":"add","path":"/admin","value":false},{"op":"replace","path":"/password","value":"****"},{"op":"replace","path":"/tags/1","value":"x"},{"op":"remove","path":"/tags/2"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, err := tt.patch(before, after)
+			if err != nil {
+				t.Fatalf("JSONPatch() error = %v", err)
+			}
+
+			got, err := CompactJSON(ops)
+			if err != nil {
+				t.Fatalf("CompactJSON() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("JSONPatch() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	before := map[string]any{
+		"title":    "Goodbye!",
+		"password": "secret",
+		"author":   map[string]any{"givenName": "John", "familyName": "Doe"},
+		"tags":     []string{"example", "sample"},
+		"content":  "This will be unchanged",
+	}
+
+	after := map[string]any{
+		"title":    "Hello!",
+		"password": "changed",
+		"author":   map[string]any{"givenName": "John"},
+		"tags":     []string{"example"},
+		"content":  "This will be unchanged",
+		"phone":    "+01-123-456-7890",
+	}
+
+	tests := []struct {
+		name  string
+		patch func(a, b any) (any, error)
+		want  string
+	}{
+		{
+			name:  "merge patch",
+			patch: MergePatch,
+			want:  `{"author":{"familyName":null},"password":"changed","phone":"+01-123-456-7890","tags":["example"],"title":"Hello!"}`,
+		},
+		{
+			name:  "secure merge patch",
+			patch: SecureMergePatch,
+			want:  `{"author":{"familyName":null},"password":"****","phone":"+01-123-456-7890","tags":["example"],"title":"Hello!"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := tt.patch(before, after)
+			if err != nil {
+				t.Fatalf("MergePatch() error = %v", err)
+			}
+
+			got := MaybeCompactJSON(patch)
+			if got != tt.want {
+				t.Errorf("MergePatch() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
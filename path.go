@@ -0,0 +1,57 @@
+package print
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+const rootPath = "$"
+
+// jsonTree converts data into a canonical tree made of
+// map[string]any, []any, string, json.Number, bool and nil
+// so values coming from different Go types can be compared
+func jsonTree(data any) (any, error) {
+	buffer := new(bytes.Buffer)
+	if err := encodeJSON(buffer, data, empty); err != nil {
+		return nil, err
+	}
+
+	var tree any
+	decoder := json.NewDecoder(buffer)
+	decoder.UseNumber()
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// keyPath appends a key to a path, e.g. $.user.name. Keys
+// that are not plain identifiers are quoted: $["x-api-key"]
+func keyPath(parent, key string) string {
+	if isIdentifier(key) {
+		return parent + "." + key
+	}
+	return parent + "[" + strconv.Quote(key) + "]"
+}
+
+// indexPath appends an array index to a path, e.g. $.items[0]
+func indexPath(parent string, i int) string {
+	return parent + "[" + strconv.Itoa(i) + "]"
+}
+
+func isIdentifier(key string) bool {
+	if key == empty {
+		return false
+	}
+
+	for i, r := range key {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,426 @@
+package print
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Policy modes
+const (
+	// PolicyDeny masks the values matched by the policy rules
+	PolicyDeny = "deny"
+	// PolicyAllow masks everything but the allowed values
+	PolicyAllow = "allow"
+)
+
+// Policy is a declarative masking policy, usually loaded from a
+// JSON or YAML file owned by security:
+//
+//	mode: deny
+//	strategy: fixed(4)
+//	presets:
+//	  - pci
+//	keys:
+//	  - match: fold
+//	    pattern: client_secret
+//	  - match: glob
+//	    pattern: x-*-key
+//	    strategy: fingerprint
+//	paths:
+//	  - path: $.credentials
+//	detectors:
+//	  - jwt
+//	  - name: entropy
+//	    min_length: 32
+//	allow:
+//	  keys:
+//	    - pattern: token_type
+//	  paths:
+//	    - $.credentials.user
+//
+// Strategies are fixed(n), filled, preserve_ends(start,end),
+// fingerprint and encrypt. Fingerprint and encrypt keys are read
+// from the GOPRINT_FINGERPRINT_KEY and GOPRINT_ENCRYPTION_KEY env vars
+type Policy struct {
+	// Mode is deny (default) or allow
+	Mode string `json:"mode" yaml:"mode"`
+	// Defaults includes the default masker field names and
+	// struct tags in deny mode, defaults to true
+	Defaults *bool `json:"defaults" yaml:"defaults"`
+	// Strategy is the default strategy, defaults to fixed(4)
+	Strategy string `json:"strategy" yaml:"strategy"`
+	// Presets selects built in presets by name: pci, pii or auth
+	Presets   []string         `json:"presets" yaml:"presets"`
+	Keys      []PolicyKey      `json:"keys" yaml:"keys"`
+	Paths     []PolicyPath     `json:"paths" yaml:"paths"`
+	Detectors []PolicyDetector `json:"detectors" yaml:"detectors"`
+	Allow     PolicyAllowlist  `json:"allow" yaml:"allow"`
+}
+
+// PolicyKey is a key name rule. Match is exact (default),
+// fold, glob or regex
+type PolicyKey struct {
+	Match    string `json:"match" yaml:"match"`
+	Pattern  string `json:"pattern" yaml:"pattern"`
+	Strategy string `json:"strategy" yaml:"strategy"`
+}
+
+// PolicyPath masks the value at a path, e.g. $.users[*].password
+type PolicyPath struct {
+	Path     string `json:"path" yaml:"path"`
+	Strategy string `json:"strategy" yaml:"strategy"`
+}
+
+// PolicyDetector selects a built in detector by name, e.g. jwt,
+// configures the entropy detector or defines a regex detector
+// with a name and pattern. A plain string selects by name
+type PolicyDetector struct {
+	Name      string   `json:"name" yaml:"name"`
+	Pattern   string   `json:"pattern" yaml:"pattern"`
+	MinLength int      `json:"min_length" yaml:"min_length"`
+	Threshold float64  `json:"threshold" yaml:"threshold"`
+	Allow     []string `json:"allow" yaml:"allow"`
+}
+
+// PolicyAllowlist lists values that are never masked by the
+// policy rules or the default masker in deny mode, or the only
+// values printed in the clear in allow mode
+type PolicyAllowlist struct {
+	Keys  []PolicyKey `json:"keys" yaml:"keys"`
+	Paths []string    `json:"paths" yaml:"paths"`
+}
+
+// PolicyError is a policy validation error, Rule points
+// at the offending rule, e.g. keys[2]
+type PolicyError struct {
+	Rule string
+	Err  error
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("policy %s: %s", e.Rule, e.Err)
+}
+
+func (e *PolicyError) Unwrap() error {
+	return e.Err
+}
+
+func (d *PolicyDetector) UnmarshalJSON(b []byte) error {
+	var name string
+	if json.Unmarshal(b, &name) == nil {
+		*d = PolicyDetector{Name: name}
+		return nil
+	}
+
+	type detector PolicyDetector
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode((*detector)(d))
+}
+
+func (d *PolicyDetector) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*d = PolicyDetector{Name: node.Value}
+		return nil
+	}
+
+	type detector PolicyDetector
+	return node.Decode((*detector)(d))
+}
+
+// LoadPolicy reads a policy from a .json, .yaml or .yml file
+func LoadPolicy(name string) (*Policy, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return ParsePolicyJSON(b)
+	case ".yaml", ".yml":
+		return ParsePolicyYAML(b)
+	default:
+		return nil, fmt.Errorf("unsupported policy file %s, use .json, .yaml or .yml", name)
+	}
+}
+
+// LoadPolicyMasker reads a policy file and returns its Masker,
+// e.g. to assign it to PrintMasker
+func LoadPolicyMasker(name string) (Masker, error) {
+	p, err := LoadPolicy(name)
+	if err != nil {
+		return nil, err
+	}
+	return p.Masker()
+}
+
+// ParsePolicyJSON parses a JSON policy, unknown fields are rejected
+func ParsePolicyJSON(b []byte) (*Policy, error) {
+	p := &Policy{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(p); err != nil {
+		return nil, fmt.Errorf("error parsing policy: %w", err)
+	}
+	return p, nil
+}
+
+// ParsePolicyYAML parses a YAML policy, unknown fields are rejected
+func ParsePolicyYAML(b []byte) (*Policy, error) {
+	p := &Policy{}
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(p); err != nil {
+		return nil, fmt.Errorf("error parsing policy: %w", err)
+	}
+	return p, nil
+}
+
+// Masker validates the policy and builds its Masker. Validation
+// errors are returned together, each one is a *PolicyError.
+// In deny mode the rules are chained after a new default masker,
+// not PrintMasker, and the allow list applies to all of them
+func (p *Policy) Masker() (Masker, error) {
+	b := &policyBuilder{}
+
+	strategy := b.strategy("strategy", p.Strategy, defaultStrategy)
+
+	var keys []KeyRule
+	for i, key := range p.Keys {
+		rule := fmt.Sprintf("keys[%d]", i)
+		if r, ok := b.keyRule(rule, key); ok {
+			keys = append(keys, r.WithStrategy(b.strategy(rule+".strategy", key.Strategy, strategy)))
+		}
+	}
+
+	for i, path := range p.Paths {
+		rule := fmt.Sprintf("paths[%d]", i)
+		if r, ok := b.pathRule(rule, path.Path); ok {
+			keys = append(keys, r.WithStrategy(b.strategy(rule+".strategy", path.Strategy, strategy)))
+		}
+	}
+
+	var detectors []Detector
+	for i, d := range p.Detectors {
+		if detector, ok := b.detector(fmt.Sprintf("detectors[%d]", i), d); ok {
+			detectors = append(detectors, detector)
+		}
+	}
+
+	// preset rules come last so policy rules pick the strategy
+	for i, name := range p.Presets {
+		preset, ok := presetByName(name)
+		if !ok {
+			b.fail(fmt.Sprintf("presets[%d]", i), fmt.Errorf("unknown preset %q, use pci, pii or auth", name))
+			continue
+		}
+		for _, r := range preset.Keys {
+			keys = append(keys, r.WithStrategy(strategy))
+		}
+		detectors = append(detectors, preset.Detectors...)
+	}
+
+	var allowKeys, allowPaths []KeyRule
+	for i, key := range p.Allow.Keys {
+		if r, ok := b.keyRule(fmt.Sprintf("allow.keys[%d]", i), key); ok {
+			allowKeys = append(allowKeys, r)
+		}
+	}
+	for i, path := range p.Allow.Paths {
+		if r, ok := b.pathRule(fmt.Sprintf("allow.paths[%d]", i), path); ok {
+			allowPaths = append(allowPaths, r)
+		}
+	}
+
+	switch p.Mode {
+	case empty, PolicyDeny:
+	case PolicyAllow:
+		if len(keys) > 0 || len(detectors) > 0 {
+			b.fail("mode", errors.New("allow mode only supports the allow list"))
+		}
+	default:
+		b.fail("mode", fmt.Errorf("unknown mode %q, use deny or allow", p.Mode))
+	}
+
+	if err := errors.Join(b.errs...); err != nil {
+		return nil, err
+	}
+
+	if p.Mode == PolicyAllow {
+		m := NewAllowMasker().AllowKeys(allowKeys...).WithStrategy(strategy)
+		for _, path := range p.Allow.Paths {
+			m.AllowPaths(path)
+		}
+		return m, nil
+	}
+
+	except := append(allowKeys, allowPaths...)
+
+	var chain []Masker
+	if p.Defaults == nil || *p.Defaults {
+		// a fresh default masker, reloading a policy into
+		// PrintMasker must not chain the previous policy
+		var defaults Masker = newDefaultMasker()
+		if skip := exceptRules(except); skip != nil {
+			defaults = exceptMasker{masker: defaults, skip: skip}
+		}
+		chain = append(chain, defaults)
+	}
+	if len(keys) > 0 {
+		chain = append(chain, NewKeyMasker(keys...).Except(except...))
+	}
+	if len(detectors) > 0 {
+		chain = append(chain, NewDetectorMasker(detectors...).WithStrategy(strategy).Except(except...))
+	}
+	return ChainMasker(chain...), nil
+}
+
+// policyBuilder collects validation errors while
+// building the rules of a policy
+type policyBuilder struct {
+	errs []error
+}
+
+func (b *policyBuilder) fail(rule string, err error) {
+	b.errs = append(b.errs, &PolicyError{Rule: rule, Err: err})
+}
+
+func (b *policyBuilder) keyRule(rule string, key PolicyKey) (KeyRule, bool) {
+	if key.Pattern == empty {
+		b.fail(rule, errors.New("missing pattern"))
+		return KeyRule{}, false
+	}
+
+	switch key.Match {
+	case empty, KeyExact.String():
+		return ExactKey(key.Pattern), true
+	case KeyFold.String():
+		return FoldKey(key.Pattern), true
+	case KeyGlob.String():
+		if _, err := path.Match(key.Pattern, empty); err != nil {
+			b.fail(rule, fmt.Errorf("invalid glob %q: %w", key.Pattern, err))
+			return KeyRule{}, false
+		}
+		return GlobKey(key.Pattern), true
+	case KeyRegex.String():
+		re, err := regexp.Compile(key.Pattern)
+		if err != nil {
+			b.fail(rule, fmt.Errorf("invalid regex: %w", err))
+			return KeyRule{}, false
+		}
+		return RegexKey(re), true
+	default:
+		b.fail(rule, fmt.Errorf("unknown match %q, use exact, fold, glob or regex", key.Match))
+		return KeyRule{}, false
+	}
+}
+
+func (b *policyBuilder) pathRule(rule, path string) (KeyRule, bool) {
+	if path == empty {
+		b.fail(rule, errors.New("missing path"))
+		return KeyRule{}, false
+	}
+	return PathRule(path), true
+}
+
+func (b *policyBuilder) detector(rule string, d PolicyDetector) (Detector, bool) {
+	if d.Pattern != empty {
+		re, err := regexp.Compile(d.Pattern)
+		if err != nil {
+			b.fail(rule, fmt.Errorf("invalid regex: %w", err))
+			return nil, false
+		}
+		name := d.Name
+		if name == empty {
+			name = "custom"
+		}
+		return NewRegexDetector(name, re), true
+	}
+
+	if d.Name == "entropy" {
+		e := NewEntropyDetector()
+		if d.MinLength > 0 {
+			e.MinLength = d.MinLength
+		}
+		if d.Threshold > 0 {
+			e.Threshold = d.Threshold
+		}
+		for _, allow := range d.Allow {
+			re, err := regexp.Compile(allow)
+			if err != nil {
+				b.fail(rule, fmt.Errorf("invalid allow regex: %w", err))
+				return nil, false
+			}
+			e.Allow = append(e.Allow, re)
+		}
+		return e, true
+	}
+
+	for _, detector := range builtinDetectors() {
+		if detector.Name() == d.Name {
+			return detector, true
+		}
+	}
+
+	b.fail(rule, fmt.Errorf("unknown detector %q", d.Name))
+	return nil, false
+}
+
+// strategy parses a strategy name, empty names use fallback
+func (b *policyBuilder) strategy(rule, name string, fallback MaskStrategy) MaskStrategy {
+	if name == empty {
+		return fallback
+	}
+
+	s, err := parseStrategy(name)
+	if err != nil {
+		b.fail(rule, err)
+		return fallback
+	}
+	return s
+}
+
+// parseStrategy parses strategies like fixed(4) or preserve_ends(4,4)
+func parseStrategy(name string) (MaskStrategy, error) {
+	kind, args := name, []int(nil)
+	if open := strings.Index(name, "("); open > 0 && strings.HasSuffix(name, ")") {
+		kind = name[:open]
+		for _, arg := range strings.Split(name[open+1:len(name)-1], ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(arg))
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid strategy %q", name)
+			}
+			args = append(args, n)
+		}
+	}
+
+	switch {
+	case kind == "filled" && len(args) == 0:
+		return MaskFilled(), nil
+	case kind == "fixed" && len(args) == 0:
+		return defaultStrategy, nil
+	case kind == "fixed" && len(args) == 1:
+		return MaskFixed(args[0]), nil
+	case kind == "preserve_ends" && len(args) == 0:
+		return MaskPreserveEnds(4, 4), nil
+	case kind == "preserve_ends" && len(args) == 2:
+		return MaskPreserveEnds(args[0], args[1]), nil
+	case kind == "fingerprint" && len(args) == 0:
+		return MaskFingerprintFromEnv()
+	case kind == "encrypt" && len(args) == 0:
+		return MaskEncryptFromEnv()
+	default:
+		return nil, fmt.Errorf("unknown strategy %q, use fixed(n), filled, preserve_ends(start,end), fingerprint or encrypt", name)
+	}
+}
@@ -0,0 +1,141 @@
+package print
+
+import "strings"
+
+// Preset is a ready made set of key rules and detectors
+// for a compliance regime. Compose presets with Presets:
+//
+//	print.PrintMasker = print.ChainMasker(print.PrintMasker, print.Presets(print.PCI, print.PII))
+type Preset struct {
+	Name      string
+	Keys      []KeyRule
+	Detectors []Detector
+}
+
+// Built in presets
+var (
+	// PCI masks cardholder data: card numbers (PAN), card
+	// verification codes, PINs and magnetic stripe track data
+	PCI = Preset{
+		Name: "pci",
+		Keys: []KeyRule{
+			GlobKey("*card_number"),
+			GlobKey("*cardnumber"),
+			FoldKey("pan"),
+			FoldKey("cc_number"),
+			FoldKey("credit_card"),
+			FoldKey("cvv"),
+			FoldKey("cvv2"),
+			FoldKey("cvc"),
+			FoldKey("cvc2"),
+			FoldKey("cid"),
+			FoldKey("security_code"),
+			GlobKey("card_verification*"),
+			FoldKey("pin"),
+			FoldKey("pin_block"),
+			GlobKey("track*_data"),
+			GlobKey("track[12]"),
+		},
+		Detectors: []Detector{CreditCardDetector, TrackDataDetector},
+	}
+
+	// PII masks personal data covered by GDPR: emails, phone
+	// numbers, national IDs, IP addresses, names and addresses
+	PII = Preset{
+		Name: "pii",
+		Keys: []KeyRule{
+			GlobKey("*email"),
+			GlobKey("*phone"),
+			GlobKey("*phone_number"),
+			FoldKey("mobile"),
+			FoldKey("first_name"),
+			FoldKey("last_name"),
+			FoldKey("middle_name"),
+			FoldKey("full_name"),
+			FoldKey("given_name"),
+			FoldKey("family_name"),
+			FoldKey("maiden_name"),
+			FoldKey("surname"),
+			FoldKey("firstname"),
+			FoldKey("lastname"),
+			FoldKey("date_of_birth"),
+			FoldKey("dob"),
+			FoldKey("birthdate"),
+			FoldKey("ssn"),
+			FoldKey("national_id"),
+			FoldKey("tax_id"),
+			FoldKey("passport_number"),
+			FoldKey("drivers_license"),
+			GlobKey("*address_line*"),
+			FoldKey("street_address"),
+			FoldKey("postal_code"),
+			FoldKey("ip"),
+			FoldKey("ip_address"),
+			FoldKey("remote_addr"),
+		},
+		Detectors: []Detector{EmailDetector, PhoneDetector, NationalIDDetector, IPAddressDetector},
+	}
+
+	// Auth masks authentication material: passwords, secrets,
+	// tokens, API keys, cookies, sessions and private keys
+	Auth = Preset{
+		Name: "auth",
+		Keys: []KeyRule{
+			GlobKey("*password*"),
+			GlobKey("*passwd*"),
+			FoldKey("pwd"),
+			GlobKey("*secret*"),
+			GlobKey("*token"),
+			GlobKey("*api_key"),
+			GlobKey("*apikey"),
+			GlobKey("x-*-key"),
+			GlobKey("*private_key"),
+			FoldKey("authorization"),
+			FoldKey("proxy-authorization"),
+			FoldKey("cookie"),
+			FoldKey("set-cookie"),
+			GlobKey("session*"),
+			FoldKey("sid"),
+			FoldKey("credentials"),
+		},
+		Detectors: []Detector{
+			JWTDetector,
+			BearerDetector,
+			AWSKeyDetector,
+			GitHubTokenDetector,
+			SlackTokenDetector,
+			PrivateKeyDetector,
+			URLCredentialsDetector,
+		},
+	}
+)
+
+// Presets returns a Masker masking the keys and
+// detector matches of all the given presets
+func Presets(presets ...Preset) Masker {
+	var keys []KeyRule
+	var detectors []Detector
+	seen := make(map[string]bool)
+	for _, preset := range presets {
+		keys = append(keys, preset.Keys...)
+		for _, d := range preset.Detectors {
+			if !seen[d.Name()] {
+				seen[d.Name()] = true
+				detectors = append(detectors, d)
+			}
+		}
+	}
+	return ChainMasker(NewKeyMasker(keys...), NewDetectorMasker(detectors...))
+}
+
+// builtinPresets lists the presets selectable by name
+var builtinPresets = []Preset{PCI, PII, Auth}
+
+func presetByName(name string) (Preset, bool) {
+	for _, preset := range builtinPresets {
+		if strings.EqualFold(preset.Name, name) {
+			return preset, true
+		}
+	}
+	return Preset{}, false
+}
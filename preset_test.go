@@ -0,0 +1,125 @@
+package print
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPresetFixtures(t *testing.T) {
+	original := PrintMasker
+	defer func() { PrintMasker = original }()
+
+	for _, preset := range builtinPresets {
+		t.Run(preset.Name, func(t *testing.T) {
+			var input map[string]any
+			b, err := os.ReadFile(filepath.Join("testdata", "presets", preset.Name+".json"))
+			if err != nil {
+				t.Fatalf("ReadFile() error = %v", err)
+			}
+			if err := json.Unmarshal(b, &input); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			PrintMasker = Presets(preset)
+			got, err := SecureJSON(input)
+			if err != nil {
+				t.Fatalf("SecureJSON() error = %v", err)
+			}
+
+			want, err := os.ReadFile(filepath.Join("testdata", "presets", preset.Name+".golden.json"))
+			if err != nil {
+				t.Fatalf("ReadFile() error = %v", err)
+			}
+			if got != strings.TrimSuffix(string(want), "\n") {
+				t.Errorf("SecureJSON() = %s\nwant %s", got, want)
+			}
+		})
+	}
+}
+
+func TestPresetsCompose(t *testing.T) {
+	m := Presets(PCI, PII, PII)
+
+	got, err := m.Mask(map[string]any{
+		"cvv":   "123",
+		"email": "jane@example.com",
+		"note":  "card 4111111111111111 for jane@example.com",
+		"city":  "Springfield",
+	})
+	if err != nil {
+		t.Fatalf("Mask() error = %v", err)
+	}
+
+	want := map[string]any{
+		"cvv":   "****",
+		"email": "****",
+		"note":  "card **** for ****",
+		"city":  "Springfield",
+	}
+	values := got.(map[string]any)
+	for key, value := range want {
+		if values[key] != value {
+			t.Errorf("Mask()[%s] = %v, want %v", key, values[key], value)
+		}
+	}
+}
+
+func TestComplianceDetectors(t *testing.T) {
+	tests := []struct {
+		detector Detector
+		input    string
+		want     []string
+	}{
+		{PhoneDetector, "call +442071838750 or (415) 555-0123", []string{"+442071838750", "(415) 555-0123"}},
+		{PhoneDetector, "+44 20 7946 0958 or 415-555-0123", []string{"+44 20 7946 0958", "415-555-0123"}},
+		{PhoneDetector, "on 2024-01-02 at 12:30", nil},
+		{PhoneDetector, "order 2024 1231 1234", nil},
+		{PhoneDetector, "build 1 2024 12 31 and v1.2024.1231", nil},
+		{IPAddressDetector, "from 10.0.0.1 and fe80::1", []string{"10.0.0.1", "fe80::1"}},
+		{IPAddressDetector, "[2001:db8::1]:8080, ::ffff:192.0.2.1 or 10.0.0.1:443.", []string{"2001:db8::1", "::ffff:192.0.2.1", "10.0.0.1"}},
+		{IPAddressDetector, "at 12:30:45, 999.1.1.1", nil},
+		{IPAddressDetector, "std::vector, Foo::bar and ::", nil},
+		{IPAddressDetector, "version 1.2.3.4.5 and mac aa:bb:cc:dd:ee:ff", nil},
+		{NationalIDDetector, "ssn 123-45-6789, ni AB123456C", []string{"123-45-6789", "AB123456C"}},
+		{NationalIDDetector, "ssn 000-12-3456 or 666-12-3456", nil},
+		{TrackDataDetector, "raw ;4111111111111111=29121010000000000000? end", []string{";4111111111111111=29121010000000000000?"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.detector.Name()+" "+tt.input, func(t *testing.T) {
+			var got []string
+			for _, span := range tt.detector.Detect(tt.input) {
+				got = append(got, tt.input[span[0]:span[1]])
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("Detect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyPresets(t *testing.T) {
+	p, err := ParsePolicyYAML([]byte("defaults: false\npresets: [pci, vault]\n"))
+	if err != nil {
+		t.Fatalf("ParsePolicyYAML() error = %v", err)
+	}
+	if _, err := p.Masker(); err == nil || !strings.Contains(err.Error(), `policy presets[1]: unknown preset "vault"`) {
+		t.Fatalf("Masker() error = %v", err)
+	}
+
+	p.Presets = []string{"PCI"}
+	m, err := p.Masker()
+	if err != nil {
+		t.Fatalf("Masker() error = %v", err)
+	}
+	got, err := m.Mask(map[string]any{"cvv": "123"})
+	if err != nil {
+		t.Fatalf("Mask() error = %v", err)
+	}
+	if got.(map[string]any)["cvv"] != "****" {
+		t.Errorf("Mask() = %v", got)
+	}
+}
@@ -0,0 +1,240 @@
+// Package printchroma provides a print.Highlighter backed by chroma
+// styles and formatters. It lives in its own package so the print
+// package does not depend on chroma:
+//
+//	p := print.NewPrinter(print.WithHighlighter(printchroma.New(
+//	    printchroma.WithStyle("github"),
+//	)))
+//
+// Importing it registers the highlighter with the print package,
+// so GOPRINT_STYLE and GOPRINT_FORMATTER also configure the
+// package level functions, e.g. print.HighlightJSON:
+//
+//	import _ "github.com/goliatone/go-print/printchroma"
+package printchroma
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/goliatone/go-print"
+)
+
+// Highlighting defaults, used unless overridden by
+// options or env vars
+const (
+	DefaultStyle     = "nord"
+	DefaultFormatter = "terminal16m"
+	// DefaultMaskedStyle is the chroma style entry
+	// used to render masked values
+	DefaultMaskedStyle = "italic #bf616a"
+)
+
+// Env vars used to configure highlighting
+const (
+	StyleEnv     = print.StyleEnv
+	FormatterEnv = print.FormatterEnv
+)
+
+func init() {
+	print.RegisterEnvHighlighter(func() print.Highlighter {
+		return New()
+	})
+}
+
+// Highlighter colours tokens using a chroma style and
+// formatter. Masked values use the MaskedStyle entry
+type Highlighter struct {
+	Style       string
+	Formatter   string
+	MaskedStyle string
+}
+
+// Option configures a Highlighter
+type Option func(*Highlighter)
+
+// WithStyle sets the chroma style, e.g. nord or github
+func WithStyle(name string) Option {
+	return func(h *Highlighter) {
+		h.Style = name
+	}
+}
+
+// WithFormatter sets the chroma formatter, e.g. terminal,
+// terminal256, terminal16m, html or noop
+func WithFormatter(name string) Option {
+	return func(h *Highlighter) {
+		h.Formatter = name
+	}
+}
+
+// WithColorDepth selects the terminal formatter
+// matching the colour depth
+func WithColorDepth(depth print.ColorDepth) Option {
+	return func(h *Highlighter) {
+		h.Formatter = Formatter(depth)
+	}
+}
+
+// WithMaskedStyle sets the chroma style entry used to
+// render masked values, e.g. "bold #ff0000"
+func WithMaskedStyle(entry string) Option {
+	return func(h *Highlighter) {
+		h.MaskedStyle = entry
+	}
+}
+
+// New creates a Highlighter. Defaults can be overridden with the
+// GOPRINT_STYLE, GOPRINT_FORMATTER and GOPRINT_COLOR_DEPTH env
+// vars, options take precedence. Unless a formatter or colour
+// depth is configured, it is inferred from COLORTERM and TERM
+func New(opts ...Option) *Highlighter {
+	h := &Highlighter{
+		Style:       DefaultStyle,
+		Formatter:   DefaultFormatter,
+		MaskedStyle: DefaultMaskedStyle,
+	}
+
+	if depth, ok := print.DetectColorDepth(); ok {
+		h.Formatter = Formatter(depth)
+	}
+
+	if depth, ok := print.ParseColorDepth(os.Getenv(print.ColorDepthEnv)); ok {
+		h.Formatter = Formatter(depth)
+	}
+
+	if v := os.Getenv(FormatterEnv); v != "" {
+		h.Formatter = v
+	}
+
+	if v := os.Getenv(StyleEnv); v != "" {
+		h.Style = v
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// Formatter returns the name of the chroma terminal
+// formatter for the colour depth
+func Formatter(depth print.ColorDepth) string {
+	switch {
+	case depth >= print.TrueColor:
+		return "terminal16m"
+	case depth >= print.Color256:
+		return "terminal256"
+	case depth >= print.Color16:
+		return "terminal16"
+	default:
+		return "terminal8"
+	}
+}
+
+// Highlight implements print.Highlighter
+func (h *Highlighter) Highlight(w io.Writer, tokens []print.Token) error {
+	formatter := formatters.Get(h.Formatter)
+	if formatter == nil {
+		formatter = formatters.Fallback
+	}
+
+	style, err := h.style()
+	if err != nil {
+		return err
+	}
+
+	out := make([]chroma.Token, len(tokens))
+	for i, token := range tokens {
+		out[i] = chroma.Token{Type: tokenType(token), Value: token.Value}
+	}
+
+	return formatter.Format(w, style, chroma.Literator(out...))
+}
+
+func (h *Highlighter) style() (*chroma.Style, error) {
+	style := styles.Get(h.Style)
+	if style == nil {
+		style = styles.Fallback
+	}
+
+	if h.MaskedStyle == "" {
+		return style, nil
+	}
+	return style.Builder().Add(chroma.GenericDeleted, h.MaskedStyle).Build()
+}
+
+func tokenType(token print.Token) chroma.TokenType {
+	if token.Masked {
+		return chroma.GenericDeleted
+	}
+
+	switch token.Kind {
+	case print.TokenKey:
+		return chroma.NameTag
+	case print.TokenString:
+		return chroma.LiteralStringDouble
+	case print.TokenNumber:
+		return chroma.LiteralNumber
+	case print.TokenBool, print.TokenNull:
+		return chroma.KeywordConstant
+	case print.TokenPunctuation:
+		return chroma.Punctuation
+	case print.TokenLineNumber, print.TokenGuide, print.TokenComment:
+		return chroma.CommentSingle
+	default:
+		return chroma.TextWhitespace
+	}
+}
+
+// HTMLPalette returns the colours of a chroma style for HTML
+// output, masked values use DefaultMaskedStyle:
+//
+//	p := print.NewPrinter(print.WithHTMLPalette(printchroma.HTMLPalette("github")))
+func HTMLPalette(name string) print.HTMLPalette {
+	h := &Highlighter{Style: name, MaskedStyle: DefaultMaskedStyle}
+
+	style, err := h.style()
+	if err != nil {
+		style = styles.Fallback
+	}
+
+	return print.HTMLPalette{
+		Background:  css(style.Get(chroma.Background), true),
+		Key:         css(style.Get(chroma.NameTag), false),
+		String:      css(style.Get(chroma.LiteralStringDouble), false),
+		Number:      css(style.Get(chroma.LiteralNumber), false),
+		Bool:        css(style.Get(chroma.KeywordConstant), false),
+		Null:        css(style.Get(chroma.KeywordConstant), false),
+		Punctuation: css(style.Get(chroma.Punctuation), false),
+		Comment:     css(style.Get(chroma.CommentSingle), false),
+		Masked:      css(style.Get(chroma.GenericDeleted), false),
+	}
+}
+
+// css converts a chroma style entry into CSS declarations, the
+// background is only kept for the entry of the whole fragment
+func css(entry chroma.StyleEntry, background bool) string {
+	var out []string
+	if entry.Colour.IsSet() {
+		out = append(out, "color:"+entry.Colour.String())
+	}
+	if background && entry.Background.IsSet() {
+		out = append(out, "background-color:"+entry.Background.String())
+	}
+	if entry.Bold == chroma.Yes {
+		out = append(out, "font-weight:bold")
+	}
+	if entry.Italic == chroma.Yes {
+		out = append(out, "font-style:italic")
+	}
+	if entry.Underline == chroma.Yes {
+		out = append(out, "text-decoration:underline")
+	}
+	return strings.Join(out, ";")
+}
@@ -0,0 +1,130 @@
+package printchroma
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-print"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name          string
+		env           map[string]string
+		opts          []Option
+		wantStyle     string
+		wantFormatter string
+	}{
+		{
+			name:          "defaults",
+			wantStyle:     DefaultStyle,
+			wantFormatter: DefaultFormatter,
+		},
+		{
+			name:          "env vars",
+			env:           map[string]string{StyleEnv: "github", print.ColorDepthEnv: "256"},
+			wantStyle:     "github",
+			wantFormatter: "terminal256",
+		},
+		{
+			name:          "formatter env wins over color depth",
+			env:           map[string]string{FormatterEnv: "noop", print.ColorDepthEnv: "256"},
+			wantStyle:     DefaultStyle,
+			wantFormatter: "noop",
+		},
+		{
+			name:          "inferred from terminal",
+			env:           map[string]string{"TERM": "xterm-256color"},
+			wantStyle:     DefaultStyle,
+			wantFormatter: "terminal256",
+		},
+		{
+			name:          "options win over env vars",
+			env:           map[string]string{StyleEnv: "github", FormatterEnv: "html"},
+			opts:          []Option{WithStyle("monokai"), WithColorDepth(print.Color16)},
+			wantStyle:     "monokai",
+			wantFormatter: "terminal16",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{StyleEnv, FormatterEnv, print.ColorDepthEnv, "FORCE_COLOR", "COLORTERM", "TERM"} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			h := New(tt.opts...)
+			if h.Style != tt.wantStyle {
+				t.Errorf("Style = %s, want %s", h.Style, tt.wantStyle)
+			}
+			if h.Formatter != tt.wantFormatter {
+				t.Errorf("Formatter = %s, want %s", h.Formatter, tt.wantFormatter)
+			}
+		})
+	}
+}
+
+func TestHighlighter(t *testing.T) {
+	data := map[string]string{"name": "john", "password": "secret123"}
+
+	p := print.NewPrinter(
+		print.WithColorMode(print.ColorAlways),
+		print.WithHighlighter(New(WithColorDepth(print.TrueColor), WithMaskedStyle("bold #ff0000"))),
+		print.WithMaskGlyph("🔒"),
+	)
+
+	got, err := p.SecureHighlightJSON(data)
+	if err != nil {
+		t.Fatalf("SecureHighlightJSON() error = %v", err)
+	}
+
+	if !strings.Contains(got, "\x1b[1m\x1b[38;2;255;0;0m🔒 ") {
+		t.Errorf("masked value should use masked style and glyph, got %q", got)
+	}
+
+	html := print.NewPrinter(
+		print.WithColorMode(print.ColorAlways),
+		print.WithHighlighter(New(WithFormatter("html"), WithStyle("github"))),
+	).MaybeHighlightJSON(data)
+	if !strings.Contains(html, "<pre") {
+		t.Errorf("html output should contain <pre>, got %q", html)
+	}
+}
+
+func TestEnvHighlighter(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "1")
+	t.Setenv(StyleEnv, "github")
+	t.Setenv(FormatterEnv, "html")
+
+	// importing printchroma registers it for the package level functions
+	got, err := print.HighlightJSON(map[string]string{"name": "john"})
+	if err != nil {
+		t.Fatalf("HighlightJSON() error = %v", err)
+	}
+	if !strings.Contains(got, "<pre") || !strings.Contains(got, "#0550ae") {
+		t.Errorf("HighlightJSON() should use the github style and html formatter, got %q", got)
+	}
+}
+
+func TestHTMLPalette(t *testing.T) {
+	palette := HTMLPalette("github")
+
+	if !strings.Contains(palette.Background, "background-color:#ffffff") {
+		t.Errorf("Background = %q, want the style background", palette.Background)
+	}
+	if palette.Key != "color:#0550ae" {
+		t.Errorf("Key = %q, want color:#0550ae", palette.Key)
+	}
+	if palette.Masked != "color:#bf616a;font-style:italic" {
+		t.Errorf("Masked = %q, want DefaultMaskedStyle", palette.Masked)
+	}
+
+	got, err := print.NewPrinter(print.WithHTMLPalette(palette)).HTMLJSON(map[string]string{"a": "b"})
+	if err != nil {
+		t.Fatalf("HTMLJSON() error = %v", err)
+	}
+	if !strings.Contains(got, ".goprint .gp-k{color:#0550ae}") {
+		t.Errorf("HTMLJSON() should use the palette, got %s", got)
+	}
+}
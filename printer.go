@@ -0,0 +1,344 @@
+package print
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Env vars used to configure highlighting
+const (
+	// ColorDepthEnv sets the colour depth, e.g. 16, 256 or truecolor
+	ColorDepthEnv = "GOPRINT_COLOR_DEPTH"
+	// FormatterEnv sets the output format, e.g. terminal256,
+	// terminal16m or noop to disable colours
+	FormatterEnv = "GOPRINT_FORMATTER"
+	// StyleEnv sets the style of the registered highlighter,
+	// e.g. github, see RegisterEnvHighlighter
+	StyleEnv = "GOPRINT_STYLE"
+)
+
+// envHighlighter creates the default Highlighter
+// when GOPRINT_STYLE or GOPRINT_FORMATTER is set
+var envHighlighter func() Highlighter
+
+// RegisterEnvHighlighter sets the Highlighter used by default when
+// GOPRINT_STYLE or GOPRINT_FORMATTER is set. It is meant to be
+// called from init, printchroma registers itself when imported:
+//
+//	import _ "github.com/goliatone/go-print/printchroma"
+func RegisterEnvHighlighter(f func() Highlighter) {
+	envHighlighter = f
+}
+
+// ColorDepth is the number of colours supported by a terminal
+type ColorDepth int
+
+const (
+	Color8    ColorDepth = 8
+	Color16   ColorDepth = 16
+	Color256  ColorDepth = 256
+	TrueColor ColorDepth = 1 << 24
+)
+
+// ParseColorDepth parses values like 8, 16, 256,
+// truecolor, 24bit or 16m
+func ParseColorDepth(s string) (ColorDepth, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "8":
+		return Color8, true
+	case "16":
+		return Color16, true
+	case "256":
+		return Color256, true
+	case "truecolor", "24bit", "16m":
+		return TrueColor, true
+	}
+	return 0, false
+}
+
+// parseFormatter maps the terminal formatter names
+// to a colour depth, noop disables colours
+func parseFormatter(s string) (ColorDepth, ColorMode, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "noop":
+		return 0, ColorNever, true
+	case "terminal8":
+		return Color8, ColorAuto, true
+	case "terminal", "terminal16":
+		return Color16, ColorAuto, true
+	case "terminal256":
+		return Color256, ColorAuto, true
+	case "terminal16m":
+		return TrueColor, ColorAuto, true
+	}
+	return 0, ColorAuto, false
+}
+
+// Printer holds the configuration used to highlight output.
+// The zero value is not usable, use NewPrinter
+type Printer struct {
+	depth       ColorDepth
+	colorMode   ColorMode
+	maskGlyph   string
+	highlighter Highlighter
+	html        HTMLPalette
+	layout      layout
+	maxItems    int
+	masker      Masker
+	audience    *LevelMasker
+}
+
+// Option configures a Printer
+type Option func(*Printer)
+
+// WithColorDepth sets the colour depth used to pick the palette
+// of the default highlighter, by default it is inferred from
+// COLORTERM and TERM
+func WithColorDepth(depth ColorDepth) Option {
+	return func(p *Printer) {
+		p.depth = depth
+	}
+}
+
+// WithColorMode sets when terminal output is coloured,
+// defaults to ColorAuto
+func WithColorMode(mode ColorMode) Option {
+	return func(p *Printer) {
+		p.colorMode = mode
+	}
+}
+
+// WithMaskGlyph sets a glyph rendered before masked
+// values, e.g. "🔒"
+func WithMaskGlyph(glyph string) Option {
+	return func(p *Printer) {
+		p.maskGlyph = glyph
+	}
+}
+
+// WithHighlighter sets the Highlighter used to colour output,
+// e.g. printchroma.New(). By default output is coloured by an
+// ANSIHighlighter matching the colour depth
+func WithHighlighter(h Highlighter) Option {
+	return func(p *Printer) {
+		p.highlighter = h
+	}
+}
+
+// WithLineNumbers prefixes each line with its number
+func WithLineNumbers() Option {
+	return func(p *Printer) {
+		p.layout.lineNumbers = true
+	}
+}
+
+// WithDepthGuides draws a guide for each nesting level
+// in place of the indentation
+func WithDepthGuides() Option {
+	return func(p *Printer) {
+		p.layout.guides = true
+	}
+}
+
+// WithWrap soft wraps long strings at width columns, keeping
+// the indentation. A width <= 0 uses the terminal width
+func WithWrap(width int) Option {
+	return func(p *Printer) {
+		p.layout.wrap = true
+		p.layout.width = width
+	}
+}
+
+// WithMasker sets the Masker used by the secure methods,
+// by default they use PrintMasker
+func WithMasker(m Masker) Option {
+	return func(p *Printer) {
+		p.masker = m
+	}
+}
+
+// WithAudience masks the values classified above audience in
+// the secure methods, on top of the configured Masker. Printers
+// for different audiences can be used concurrently:
+//
+//	internal := print.NewPrinter(print.WithAudience(print.LevelInternal))
+//	public := print.NewPrinter(print.WithAudience(print.LevelPublic))
+//
+// Use WithMasker and a LevelMasker to classify key rules
+func WithAudience(audience Level) Option {
+	return func(p *Printer) {
+		p.audience = NewLevelMasker(audience)
+	}
+}
+
+// NewPrinter creates a Printer. Defaults can be overridden with
+// the GOPRINT_COLOR_DEPTH, GOPRINT_FORMATTER and GOPRINT_STYLE env
+// vars, options take precedence. GOPRINT_STYLE needs a registered
+// highlighter, see RegisterEnvHighlighter
+func NewPrinter(opts ...Option) *Printer {
+	p := &Printer{html: DefaultHTMLPalette}
+
+	if depth, ok := ParseColorDepth(os.Getenv(ColorDepthEnv)); ok {
+		p.depth = depth
+	}
+
+	style, formatter := os.Getenv(StyleEnv), os.Getenv(FormatterEnv)
+	if depth, mode, ok := parseFormatter(formatter); ok {
+		p.depth, p.colorMode = depth, mode
+	}
+
+	if envHighlighter != nil && (style != empty || formatter != empty) {
+		p.highlighter = envHighlighter()
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// HighlightJSON will pretty print data as highlighted JSON.
+// Colours are only used when stdout supports them, otherwise
+// it returns plain pretty JSON
+func (p *Printer) HighlightJSON(data any) (string, error) {
+	return p.render(os.Stdout, data, false)
+}
+
+// MaybeHighlightJSON will return highlighted JSON, in case of
+// an error it will return the message: error printing
+func (p *Printer) MaybeHighlightJSON(data any) string {
+	out, err := p.HighlightJSON(data)
+	if err != nil {
+		return fmt.Sprintf("error printing: %s", err)
+	}
+	return out
+}
+
+// FprintHighlightJSON will write data as highlighted JSON to w.
+// Colours are only used when w supports them
+func (p *Printer) FprintHighlightJSON(w io.Writer, data any) error {
+	return p.fprint(w, data, false)
+}
+
+// SecureHighlightJSON will mask sensitive data and print it
+// as highlighted JSON. Masked values are rendered using the
+// masked style so they stand out from regular strings
+func (p *Printer) SecureHighlightJSON(data any) (string, error) {
+	return p.render(os.Stdout, data, true)
+}
+
+// MaybeSecureHighlightJSON will return masked highlighted JSON, in
+// case of an error it will return the message: error printing
+func (p *Printer) MaybeSecureHighlightJSON(data any) string {
+	out, err := p.SecureHighlightJSON(data)
+	if err != nil {
+		return fmt.Sprintf("error printing: %s", err)
+	}
+	return out
+}
+
+// FprintSecureHighlightJSON will mask sensitive data and write
+// it as highlighted JSON to w
+func (p *Printer) FprintSecureHighlightJSON(w io.Writer, data any) error {
+	return p.fprint(w, data, true)
+}
+
+func (p *Printer) fprint(w io.Writer, data any, secure bool) error {
+	out, err := p.render(w, data, secure)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, out)
+	return err
+}
+
+// render prints data for the target w as tokens,
+// secure output flags the masked values
+func (p *Printer) render(w io.Writer, data any, secure bool) (string, error) {
+	if secure {
+		tokens, err := secureTokens(p.secureMasker(), data)
+		if err != nil {
+			return empty, err
+		}
+		return p.highlightTokens(w, tokens)
+	}
+
+	tree, err := jsonTree(data)
+	if err != nil {
+		return empty, err
+	}
+	return p.highlightTokens(w, jsonTokens(tree, nil))
+}
+
+// secureMasker returns the Masker used by the secure methods
+func (p *Printer) secureMasker() Masker {
+	m := p.masker
+	if m == nil {
+		m = PrintMasker
+	}
+	if p.audience != nil {
+		return ChainMasker(m, p.audience)
+	}
+	return m
+}
+
+// colorDepth returns the configured colour depth or the one
+// inferred from the environment, 256 colours when unknown
+func (p *Printer) colorDepth() ColorDepth {
+	if p.depth != 0 {
+		return p.depth
+	}
+	if depth, ok := DetectColorDepth(); ok {
+		return depth
+	}
+	return Color256
+}
+
+// highlightTokens colours tokens using the configured Highlighter,
+// it falls back to plain text when w has no colour
+func (p *Printer) highlightTokens(w io.Writer, tokens []Token) (string, error) {
+	color := colorEnabled(w, p.colorMode)
+	if color {
+		// marked before wrapping so the glyph is only rendered once
+		tokens = markMasked(tokens, p.maskGlyph)
+	}
+
+	if p.layout.enabled() {
+		tokens = p.layout.apply(tokens)
+	}
+
+	if !color {
+		return joinTokens(tokens), nil
+	}
+
+	h := p.highlighter
+	if h == nil {
+		h = NewANSIHighlighter(p.colorDepth())
+	}
+
+	var buf bytes.Buffer
+	if err := h.Highlight(&buf, tokens); err != nil {
+		return empty, fmt.Errorf("error highlighting: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// markMasked prefixes masked values with glyph
+func markMasked(tokens []Token, glyph string) []Token {
+	if glyph == empty {
+		return tokens
+	}
+
+	out := make([]Token, len(tokens))
+	for i, token := range tokens {
+		if token.Masked {
+			token.Value = glyph + " " + token.Value
+		}
+		out[i] = token
+	}
+	return out
+}
@@ -0,0 +1,202 @@
+package print
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestNewPrinterColorDepth(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		opts []Option
+		want ColorDepth
+	}{
+		{
+			name: "defaults",
+			want: Color256,
+		},
+		{
+			name: "inferred from terminal",
+			env:  map[string]string{"COLORTERM": "truecolor"},
+			want: TrueColor,
+		},
+		{
+			name: "env var",
+			env:  map[string]string{ColorDepthEnv: "16", "COLORTERM": "truecolor"},
+			want: Color16,
+		},
+		{
+			name: "options win over env vars",
+			env:  map[string]string{ColorDepthEnv: "256"},
+			opts: []Option{WithColorDepth(Color16)},
+			want: Color16,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"FORCE_COLOR", "TERM", "COLORTERM", ColorDepthEnv} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			if got := NewPrinter(tt.opts...).colorDepth(); got != tt.want {
+				t.Errorf("colorDepth() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrinterHighlightJSON(t *testing.T) {
+	data := map[string]string{"password": "secret123"}
+
+	plain := NewPrinter(WithColorMode(ColorNever))
+	got, err := plain.HighlightJSON(data)
+	if err != nil {
+		t.Fatalf("HighlightJSON() error = %v", err)
+	}
+	if got != MaybePrettyJSON(data) {
+		t.Errorf("uncoloured output should return plain JSON, got %q", got)
+	}
+
+	got = plain.MaybeSecureHighlightJSON(data)
+	if strings.Contains(got, "secret123") {
+		t.Errorf("MaybeSecureHighlightJSON() leaked secret: %s", got)
+	}
+
+	colored := NewPrinter(WithColorDepth(Color256), WithColorMode(ColorAlways)).MaybeHighlightJSON(data)
+	if !strings.Contains(colored, "\x1b[38;5;") {
+		t.Errorf("256 colour output should use 256 colour codes, got %q", colored)
+	}
+}
+
+func TestParseColorDepth(t *testing.T) {
+	tests := map[string]ColorDepth{"8": Color8, "16": Color16, "256": Color256, "truecolor": TrueColor, "24BIT": TrueColor}
+	for in, want := range tests {
+		if got, ok := ParseColorDepth(in); !ok || got != want {
+			t.Errorf("ParseColorDepth(%q) = %v, %v want %v", in, got, ok, want)
+		}
+	}
+
+	if _, ok := ParseColorDepth("many"); ok {
+		t.Error("ParseColorDepth() should fail for unknown values")
+	}
+}
+
+func TestPrinterColorDetection(t *testing.T) {
+	data := map[string]string{"hello": "world"}
+	plain := MaybePrettyJSON(data)
+
+	tests := []struct {
+		name      string
+		env       map[string]string
+		opts      []Option
+		wantColor bool
+		wantCode  string
+	}{
+		{
+			name:      "not a terminal",
+			wantColor: false,
+		},
+		{
+			name:      "force color",
+			env:       map[string]string{"FORCE_COLOR": "1", "TERM": "xterm-256color"},
+			wantColor: true,
+			wantCode:  "\x1b[38;5;",
+		},
+		{
+			name:      "force color truecolor",
+			env:       map[string]string{"FORCE_COLOR": "1", "COLORTERM": "truecolor"},
+			wantColor: true,
+			wantCode:  "\x1b[38;2;",
+		},
+		{
+			name:      "no color wins over force color",
+			env:       map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "1"},
+			wantColor: false,
+		},
+		{
+			name:      "force color disabled",
+			env:       map[string]string{"FORCE_COLOR": "0"},
+			opts:      []Option{WithColorMode(ColorAuto)},
+			wantColor: false,
+		},
+		{
+			name:      "always",
+			env:       map[string]string{"TERM": "dumb"},
+			opts:      []Option{WithColorMode(ColorAlways), WithColorDepth(Color16)},
+			wantColor: true,
+			wantCode:  "\x1b[",
+		},
+		{
+			name:      "never",
+			env:       map[string]string{"FORCE_COLOR": "1"},
+			opts:      []Option{WithColorMode(ColorNever)},
+			wantColor: false,
+		},
+		{
+			name:      "formatter env",
+			env:       map[string]string{"FORCE_COLOR": "1", "COLORTERM": "truecolor", FormatterEnv: "terminal256"},
+			wantColor: true,
+			wantCode:  "\x1b[38;5;",
+		},
+		{
+			name:      "noop formatter",
+			env:       map[string]string{"FORCE_COLOR": "1", FormatterEnv: "noop"},
+			wantColor: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"NO_COLOR", "FORCE_COLOR", "TERM", "COLORTERM", ColorDepthEnv, FormatterEnv, StyleEnv} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			buffer := new(bytes.Buffer)
+			if err := NewPrinter(tt.opts...).FprintHighlightJSON(buffer, data); err != nil {
+				t.Fatalf("FprintHighlightJSON() error = %v", err)
+			}
+
+			got := buffer.String()
+			if !tt.wantColor && got != plain {
+				t.Errorf("expected plain JSON, got %q", got)
+			}
+			if tt.wantColor && !strings.Contains(got, tt.wantCode) {
+				t.Errorf("expected colour code %q, got %q", tt.wantCode, got)
+			}
+		})
+	}
+}
+
+type envHighlighterStub struct{}
+
+func (envHighlighterStub) Highlight(w io.Writer, tokens []Token) error {
+	_, err := io.WriteString(w, "stub")
+	return err
+}
+
+func TestRegisterEnvHighlighter(t *testing.T) {
+	original := envHighlighter
+	defer func() { envHighlighter = original }()
+
+	RegisterEnvHighlighter(func() Highlighter { return envHighlighterStub{} })
+
+	t.Setenv(FormatterEnv, "")
+	t.Setenv(StyleEnv, "")
+	if NewPrinter().highlighter != nil {
+		t.Error("NewPrinter() should use the default highlighter without env vars")
+	}
+
+	t.Setenv(StyleEnv, "github")
+	if _, ok := NewPrinter().highlighter.(envHighlighterStub); !ok {
+		t.Error("NewPrinter() should use the registered highlighter when GOPRINT_STYLE is set")
+	}
+
+	h := NewANSIHighlighter(Color16)
+	if got := NewPrinter(WithHighlighter(h)).highlighter; got != h {
+		t.Error("WithHighlighter() should win over env vars")
+	}
+}
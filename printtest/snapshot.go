@@ -0,0 +1,155 @@
+// Package printtest provides golden file snapshot helpers
+// built on top of the print package.
+//
+// Snapshots are stored as pretty printed JSON under
+// testdata/snapshots. Set GOPRINT_UPDATE_SNAPSHOTS=1 to write
+// new golden files. An -update flag defined by the test binary
+// is honoured too, printtest does not register one:
+//
+//	func TestUser(t *testing.T) {
+//	    printtest.AssertSnapshot(t, "user", user)
+//	}
+package printtest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/goliatone/go-print"
+)
+
+// UpdateEnv is the env var used to update golden files
+const UpdateEnv = "GOPRINT_UPDATE_SNAPSHOTS"
+
+// Dir is the directory where golden files are stored
+var Dir = filepath.Join("testdata", "snapshots")
+
+var (
+	uuidPattern      = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	timestampPattern = regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`)
+	unsafeNameChars  = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+)
+
+// Scrubber replaces volatile content before comparing snapshots
+type Scrubber struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// DefaultScrubbers replace UUIDs and RFC 3339 timestamps
+var DefaultScrubbers = []Scrubber{
+	{Pattern: uuidPattern, Replacement: "<uuid>"},
+	{Pattern: timestampPattern, Replacement: "<timestamp>"},
+}
+
+type config struct {
+	secure    bool
+	dir       string
+	scrubbers []Scrubber
+}
+
+// Option configures AssertSnapshot
+type Option func(*config)
+
+// WithSecure masks sensitive data before taking the snapshot
+func WithSecure() Option {
+	return func(c *config) {
+		c.secure = true
+	}
+}
+
+// WithDir stores golden files in dir instead of Dir
+func WithDir(dir string) Option {
+	return func(c *config) {
+		c.dir = dir
+	}
+}
+
+// WithScrubber adds a scrubber on top of the default ones
+func WithScrubber(pattern *regexp.Regexp, replacement string) Option {
+	return func(c *config) {
+		c.scrubbers = append(c.scrubbers, Scrubber{Pattern: pattern, Replacement: replacement})
+	}
+}
+
+// WithScrubbers replaces all scrubbers, including the default ones
+func WithScrubbers(scrubbers ...Scrubber) Option {
+	return func(c *config) {
+		c.scrubbers = scrubbers
+	}
+}
+
+// AssertSnapshot compares the pretty JSON of v against the golden
+// file testdata/snapshots/<name>.json. On mismatch it reports a
+// highlighted diff, when updating it writes the golden file
+func AssertSnapshot(t testing.TB, name string, v any, opts ...Option) {
+	t.Helper()
+
+	cfg := &config{
+		dir:       Dir,
+		scrubbers: append([]Scrubber{}, DefaultScrubbers...),
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	render := print.PrettyJSON
+	if cfg.secure {
+		render = print.SecureJSON
+	}
+
+	got, err := render(v)
+	if err != nil {
+		t.Fatalf("snapshot %s: error printing: %s", name, err)
+		return
+	}
+
+	for _, scrubber := range cfg.scrubbers {
+		got = scrubber.Pattern.ReplaceAllString(got, scrubber.Replacement)
+	}
+
+	path := filepath.Join(cfg.dir, fileName(name))
+
+	if shouldUpdate() {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("snapshot %s: %s", name, err)
+			return
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("snapshot %s: %s", name, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("snapshot %s: %s, set %s=1 to create it", name, err, UpdateEnv)
+		return
+	}
+
+	if diff := print.UnifiedDiff(string(want), got); diff != "" {
+		t.Errorf("snapshot %s does not match %s:\n%s", name, path, diff)
+	}
+}
+
+func fileName(name string) string {
+	return strings.Trim(unsafeNameChars.ReplaceAllString(name, "_"), "_") + ".json"
+}
+
+// shouldUpdate reports if golden files should be written, the
+// -update flag is looked up lazily so test binaries can define it
+func shouldUpdate() bool {
+	if f := flag.Lookup("update"); f != nil {
+		if ok, _ := strconv.ParseBool(f.Value.String()); ok {
+			return true
+		}
+	}
+	ok, _ := strconv.ParseBool(os.Getenv(UpdateEnv))
+	return ok
+}
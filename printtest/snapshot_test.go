@@ -0,0 +1,119 @@
+package printtest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// update is defined once by the test binary, printtest
+// must not register -update itself
+var update = flag.Bool("update", false, "update golden files")
+
+type user struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Password  string    `json:"password"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// recorder captures failures so we can assert on them
+type recorder struct {
+	testing.TB
+	failed  bool
+	message string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failed = true
+	r.message = fmt.Sprintf(format, args...)
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+}
+
+func TestAssertSnapshot(t *testing.T) {
+	u := user{
+		ID:        uuid.New(),
+		Name:      "john",
+		Password:  "secret123",
+		CreatedAt: time.Now(),
+	}
+
+	AssertSnapshot(t, "user", u)
+	AssertSnapshot(t, "user secure", u, WithSecure())
+	AssertSnapshot(t, "order", map[string]any{"order": "A-1234"},
+		WithScrubber(regexp.MustCompile(`A-\d+`), "<order>"))
+}
+
+func TestAssertSnapshotMismatch(t *testing.T) {
+	t.Setenv(UpdateEnv, "0")
+	setUpdate(t, false)
+
+	dir := t.TempDir()
+	golden := "{\n\t\"name\": \"john\"\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "user.json"), []byte(golden), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := &recorder{TB: t}
+	AssertSnapshot(rec, "user", map[string]string{"name": "jane"}, WithDir(dir))
+
+	if !rec.failed {
+		t.Fatal("AssertSnapshot() expected mismatch")
+	}
+
+	if !strings.Contains(rec.message, `"name": "jane"`) {
+		t.Errorf("AssertSnapshot() message should contain diff, got:\n%s", rec.message)
+	}
+
+	rec = &recorder{TB: t}
+	AssertSnapshot(rec, "missing", user{}, WithDir(dir))
+	if !rec.failed || !strings.Contains(rec.message, UpdateEnv) {
+		t.Errorf("AssertSnapshot() should fail for missing golden file, got: %s", rec.message)
+	}
+}
+
+func TestAssertSnapshotUpdate(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Setenv(UpdateEnv, "1")
+	AssertSnapshot(t, "new/snapshot", map[string]string{"hello": "world"}, WithDir(dir))
+
+	content, err := os.ReadFile(filepath.Join(dir, "new_snapshot.json"))
+	if err != nil {
+		t.Fatalf("golden file not written: %v", err)
+	}
+
+	want := "{\n\t\"hello\": \"world\"\n}\n"
+	if string(content) != want {
+		t.Errorf("golden file = %q, want %q", content, want)
+	}
+}
+
+func TestAssertSnapshotUpdateFlag(t *testing.T) {
+	t.Setenv(UpdateEnv, "0")
+	setUpdate(t, true)
+
+	dir := t.TempDir()
+	AssertSnapshot(t, "flag", map[string]string{"hello": "world"}, WithDir(dir))
+
+	if _, err := os.Stat(filepath.Join(dir, "flag.json")); err != nil {
+		t.Errorf("golden file not written with -update: %v", err)
+	}
+}
+
+// setUpdate sets the -update flag for the duration of the test
+func setUpdate(t *testing.T, value bool) {
+	previous := *update
+	*update = value
+	t.Cleanup(func() { *update = previous })
+}
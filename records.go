@@ -0,0 +1,159 @@
+package print
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+const valueColumn = "value"
+
+var errRecordsInput = errors.New("input must be a slice or array")
+
+// recordRows normalizes a slice of structs or maps into rows keyed by
+// column name. Struct columns keep their declaration order, keys
+// only found in maps are appended in alphabetical order
+func recordRows(data any) ([]string, []map[string]any, error) {
+	val, err := recordsValue(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items, _ := safeToJSON(val.Interface()).([]any)
+	columns, rows := records(jsonFieldNames(val.Type().Elem()), items)
+	return columns, rows, nil
+}
+
+// secureRecordRows works like recordRows, rows are masked
+func secureRecordRows(data any) ([]string, []map[string]any, error) {
+	val, err := recordsValue(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, tree, err := maskTree(val.Interface())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items, _ := tree.([]any)
+	columns, rows := records(jsonFieldNames(val.Type().Elem()), items)
+	return columns, rows, nil
+}
+
+func recordsValue(data any) (reflect.Value, error) {
+	val := reflect.ValueOf(data)
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return val, errRecordsInput
+		}
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return val, errRecordsInput
+	}
+	return val, nil
+}
+
+// records builds rows from normalized items, columns
+// lists the known columns in order
+func records(columns []string, items []any) ([]string, []map[string]any) {
+	known := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		known[column] = true
+	}
+
+	var extra []string
+	rows := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		row, ok := item.(map[string]any)
+		if !ok {
+			row = map[string]any{valueColumn: item}
+		}
+
+		for key := range row {
+			if !known[key] {
+				known[key] = true
+				extra = append(extra, key)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	sort.Strings(extra)
+
+	return append(columns, extra...), rows
+}
+
+// cellString formats a normalized value for tabular output,
+// nested values are encoded as compact JSON
+func cellString(v any) string {
+	switch value := v.(type) {
+	case nil:
+		return empty
+	case string:
+		return value
+	case map[string]any, []any:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return unsupportedMessage
+		}
+		return string(b)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+type flatField struct {
+	key   string
+	value any
+}
+
+// flattener turns a normalized tree into a list of
+// dot-path keys and scalar values
+type flattener struct {
+	separator string
+	brackets  bool
+}
+
+var defaultFlattener = flattener{separator: "."}
+
+func (f flattener) join(prefix, key string) string {
+	if prefix == empty {
+		return key
+	}
+	return prefix + f.separator + key
+}
+
+func (f flattener) index(prefix string, i int) string {
+	if f.brackets {
+		return prefix + "[" + strconv.Itoa(i) + "]"
+	}
+	return f.join(prefix, strconv.Itoa(i))
+}
+
+func (f flattener) flatten(prefix string, v any, out []flatField) []flatField {
+	switch value := v.(type) {
+	case map[string]any:
+		if len(value) == 0 {
+			return append(out, flatField{key: prefix, value: value})
+		}
+		for _, key := range sortedKeys(value) {
+			out = f.flatten(f.join(prefix, key), value[key], out)
+		}
+	case []any:
+		if len(value) == 0 {
+			return append(out, flatField{key: prefix, value: value})
+		}
+		for i, item := range value {
+			out = f.flatten(f.index(prefix, i), item, out)
+		}
+	default:
+		out = append(out, flatField{key: prefix, value: value})
+	}
+	return out
+}
@@ -0,0 +1,223 @@
+package print
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// maskTag is the struct tag read by the default masker
+const maskTag = "mask"
+
+// MaskReport lists the values masked by a secure printer
+type MaskReport struct {
+	Entries []MaskEntry `json:"entries"`
+}
+
+// MaskEntry describes a masked value. Rule is the rule or tag
+// that masked it, e.g. fold:client_secret, detector:jwt,
+// tag:filled4 or field:password for the default field names
+type MaskEntry struct {
+	Path           string `json:"path"`
+	Rule           string `json:"rule"`
+	OriginalLength int    `json:"original_length"`
+}
+
+// Masked reports if the value at path was masked
+func (r *MaskReport) Masked(path string) bool {
+	_, ok := r.Entry(path)
+	return ok
+}
+
+// Entry returns the entry for the value at path
+func (r *MaskReport) Entry(path string) (MaskEntry, bool) {
+	for _, entry := range r.Entries {
+		if entry.Path == path {
+			return entry, true
+		}
+	}
+	return MaskEntry{}, false
+}
+
+// Paths returns the masked paths in order
+func (r *MaskReport) Paths() []string {
+	paths := make([]string, len(r.Entries))
+	for i, entry := range r.Entries {
+		paths[i] = entry.Path
+	}
+	return paths
+}
+
+// SecureJSONWithReport will mask sensitive data and print it as
+// pretty JSON, the report lists every value that was masked
+func SecureJSONWithReport(data any) (string, *MaskReport, error) {
+	hits := make(map[string]string)
+	original, tree, err := maskTreeWith(PrintMasker, data, func(path, rule string) {
+		if _, ok := hits[path]; !ok {
+			hits[path] = rule
+		}
+	})
+	if err != nil {
+		return empty, nil, err
+	}
+
+	out, err := PrettyJSON(tree)
+	if err != nil {
+		return empty, nil, err
+	}
+
+	return out, newMaskReport(data, original, tree, hits), nil
+}
+
+// reportingMasker is implemented by maskers that can tell
+// which rule masked the value at each path
+type reportingMasker interface {
+	maskReport(target any, hit func(path, rule string)) (any, error)
+}
+
+// maskWith masks target with m, reporting rule hits when m supports it
+func maskWith(m Masker, target any, hit func(path, rule string)) (any, error) {
+	if r, ok := m.(reportingMasker); ok && hit != nil {
+		return r.maskReport(target, hit)
+	}
+	return m.Mask(target)
+}
+
+func (c maskerChain) maskReport(target any, hit func(path, rule string)) (any, error) {
+	var err error
+	for _, m := range c {
+		if target, err = maskWith(m, target, hit); err != nil {
+			return nil, err
+		}
+	}
+	return target, nil
+}
+
+// reportedValue is a leaf of the original tree, key is the
+// nearest map key used to name default field rules
+type reportedValue struct {
+	value any
+	key   string
+}
+
+func newMaskReport(data, original, tree any, hits map[string]string) *MaskReport {
+	tags := make(map[string]string)
+	maskTags(rootPath, reflect.ValueOf(data), tags)
+
+	values := make(map[string]reportedValue)
+	treeLeaves(rootPath, empty, original, values)
+
+	paths := make([]string, 0)
+	for path := range maskedPaths(original, tree) {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	report := &MaskReport{Entries: make([]MaskEntry, 0, len(paths))}
+	for _, path := range paths {
+		leaf := values[path]
+
+		rule, ok := lookupPath(hits, path)
+		if !ok {
+			if tag, ok := lookupPath(tags, path); ok {
+				rule = "tag:" + tag
+			} else {
+				rule = "field:" + leaf.key
+			}
+		}
+
+		report.Entries = append(report.Entries, MaskEntry{
+			Path:           path,
+			Rule:           rule,
+			OriginalLength: valueLength(leaf.value),
+		})
+	}
+	return report
+}
+
+// lookupPath returns the value stored for path or
+// for its closest ancestor
+func lookupPath(values map[string]string, path string) (string, bool) {
+	best, found := empty, false
+	longest := -1
+	for prefix, value := range values {
+		if len(prefix) <= longest || !hasPathPrefix(path, prefix) {
+			continue
+		}
+		best, found, longest = value, true, len(prefix)
+	}
+	return best, found
+}
+
+func hasPathPrefix(path, prefix string) bool {
+	if !strings.HasPrefix(path, prefix) {
+		return false
+	}
+	if len(path) == len(prefix) {
+		return true
+	}
+	next := path[len(prefix)]
+	return next == '.' || next == '['
+}
+
+// maskTags collects the mask struct tags of data by path
+func maskTags(path string, val reflect.Value, tags map[string]string) {
+	switch val.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if !val.IsNil() {
+			maskTags(path, val.Elem(), tags)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range val.Len() {
+			maskTags(indexPath(path, i), val.Index(i), tags)
+		}
+	case reflect.Map:
+		for _, key := range val.MapKeys() {
+			maskTags(keyPath(path, fmt.Sprintf("%v", key.Interface())), val.MapIndex(key), tags)
+		}
+	case reflect.Struct:
+		t := val.Type()
+		for i := range t.NumField() {
+			field := t.Field(i)
+			name, _, ok := jsonFieldName(field)
+			if !ok {
+				continue
+			}
+			if tag := field.Tag.Get(maskTag); tag != empty {
+				tags[keyPath(path, name)] = tag
+			}
+			maskTags(keyPath(path, name), val.Field(i), tags)
+		}
+	}
+}
+
+// treeLeaves collects the scalar values of a normalized tree
+func treeLeaves(path, key string, v any, out map[string]reportedValue) {
+	switch value := v.(type) {
+	case map[string]any:
+		for k, item := range value {
+			treeLeaves(keyPath(path, k), k, item, out)
+		}
+	case []any:
+		for i, item := range value {
+			treeLeaves(indexPath(path, i), key, item, out)
+		}
+	default:
+		out[path] = reportedValue{value: v, key: key}
+	}
+}
+
+// valueLength returns the length of a string in characters,
+// other values are measured by their JSON encoding
+func valueLength(v any) int {
+	switch value := v.(type) {
+	case nil:
+		return 0
+	case string:
+		return utf8.RuneCountInString(value)
+	default:
+		return len(marshalScalar(value))
+	}
+}
@@ -0,0 +1,110 @@
+package print
+
+import (
+	"strings"
+	"testing"
+)
+
+type reportCredentials struct {
+	Token    string `json:"token"`
+	Username string `json:"username"`
+}
+
+type reportAccount struct {
+	Name        string            `json:"name"`
+	PIN         string            `json:"pin" mask:"filled4"`
+	Credentials reportCredentials `json:"credentials"`
+	Metadata    map[string]any    `json:"metadata"`
+}
+
+func TestSecureJSONWithReport(t *testing.T) {
+	original := PrintMasker
+	defer func() { PrintMasker = original }()
+
+	PrintMasker = ChainMasker(
+		PrintMasker,
+		NewKeyMasker(FoldKey("client_secret")),
+		NewDetectorMasker(BearerDetector),
+	)
+
+	account := reportAccount{
+		Name:        "billing",
+		PIN:         "123456",
+		Credentials: reportCredentials{Token: "tok_1234567890", Username: "admin"},
+		Metadata: map[string]any{
+			"Client_Secret": "s3cr3t",
+			"last_error":    "401 for Bearer abcdef",
+		},
+	}
+
+	out, report, err := SecureJSONWithReport(account)
+	if err != nil {
+		t.Fatalf("SecureJSONWithReport() error = %v", err)
+	}
+
+	want, err := SecureJSON(account)
+	if err != nil {
+		t.Fatalf("SecureJSON() error = %v", err)
+	}
+	if out != want {
+		t.Errorf("SecureJSONWithReport() output differs from SecureJSON()\ngot:\n%s\nwant:\n%s", out, want)
+	}
+
+	expected := []MaskEntry{
+		{Path: "$.credentials.token", Rule: "field:token", OriginalLength: 14},
+		{Path: "$.metadata.Client_Secret", Rule: "fold:client_secret", OriginalLength: 6},
+		{Path: "$.metadata.last_error", Rule: "detector:bearer", OriginalLength: 21},
+		{Path: "$.pin", Rule: "tag:filled4", OriginalLength: 6},
+	}
+
+	if len(report.Entries) != len(expected) {
+		t.Fatalf("report has %d entries, want %d: %+v", len(report.Entries), len(expected), report.Entries)
+	}
+
+	for i, entry := range expected {
+		if report.Entries[i] != entry {
+			t.Errorf("entry %d = %+v, want %+v", i, report.Entries[i], entry)
+		}
+	}
+
+	if !report.Masked("$.credentials.token") {
+		t.Errorf("Masked($.credentials.token) = false, want true")
+	}
+
+	if report.Masked("$.credentials.username") {
+		t.Errorf("Masked($.credentials.username) = true, want false")
+	}
+
+	if got := strings.Join(report.Paths(), " "); !strings.HasPrefix(got, "$.credentials.token") {
+		t.Errorf("Paths() = %s", got)
+	}
+}
+
+func TestMaskReportNestedRule(t *testing.T) {
+	original := PrintMasker
+	defer func() { PrintMasker = original }()
+
+	PrintMasker = NewKeyMasker(ExactKey("credentials"))
+
+	_, report, err := SecureJSONWithReport(map[string]any{
+		"credentials": map[string]any{"user": "admin", "keys": []any{"a1", "b22"}},
+	})
+	if err != nil {
+		t.Fatalf("SecureJSONWithReport() error = %v", err)
+	}
+
+	for path, length := range map[string]int{
+		"$.credentials.user":    5,
+		"$.credentials.keys[0]": 2,
+		"$.credentials.keys[1]": 3,
+	} {
+		entry, ok := report.Entry(path)
+		if !ok {
+			t.Errorf("Entry(%s) missing: %+v", path, report.Entries)
+			continue
+		}
+		if entry.Rule != "exact:credentials" || entry.OriginalLength != length {
+			t.Errorf("Entry(%s) = %+v", path, entry)
+		}
+	}
+}
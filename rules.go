@@ -0,0 +1,201 @@
+package print
+
+import (
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// KeyMatch is the way a KeyRule compares key names
+type KeyMatch int
+
+const (
+	// KeyExact matches keys equal to the pattern
+	KeyExact KeyMatch = iota
+	// KeyFold matches keys equal to the pattern ignoring case
+	KeyFold
+	// KeyGlob matches keys against a shell pattern ignoring
+	// case, e.g. x-*-key
+	KeyGlob
+	// KeyRegex matches keys against a regular expression
+	KeyRegex
+	// KeyPath matches values by path instead of key name
+	KeyPath
+)
+
+func (m KeyMatch) String() string {
+	switch m {
+	case KeyFold:
+		return "fold"
+	case KeyGlob:
+		return "glob"
+	case KeyRegex:
+		return "regex"
+	case KeyPath:
+		return "path"
+	default:
+		return "exact"
+	}
+}
+
+// KeyRule masks values stored under matching key names, either
+// map keys or struct fields by their JSON or Go name
+type KeyRule struct {
+	match    KeyMatch
+	pattern  string
+	regex    *regexp.Regexp
+	strategy MaskStrategy
+}
+
+// ExactKey matches keys equal to name
+func ExactKey(name string) KeyRule {
+	return KeyRule{match: KeyExact, pattern: name}
+}
+
+// FoldKey matches keys equal to name ignoring case
+func FoldKey(name string) KeyRule {
+	return KeyRule{match: KeyFold, pattern: name}
+}
+
+// GlobKey matches keys against a shell pattern ignoring
+// case, e.g. *_secret or x-*-key
+func GlobKey(pattern string) KeyRule {
+	return KeyRule{match: KeyGlob, pattern: strings.ToLower(pattern)}
+}
+
+// RegexKey matches keys against re
+func RegexKey(re *regexp.Regexp) KeyRule {
+	return KeyRule{match: KeyRegex, pattern: re.String(), regex: re}
+}
+
+// PathRule matches the value at path and its descendants. A *
+// matches any key and [*] any index, e.g. $.users[*].password
+func PathRule(pattern string) KeyRule {
+	re := compilePathPattern(pattern)
+	return KeyRule{match: KeyPath, pattern: pattern, regex: re}
+}
+
+// WithStrategy returns a copy of the rule masking values
+// with s, by default values are replaced with "****"
+func (r KeyRule) WithStrategy(s MaskStrategy) KeyRule {
+	r.strategy = s
+	return r
+}
+
+// Matches reports if key matches the rule, path
+// rules never match a key
+func (r KeyRule) Matches(key string) bool {
+	switch r.match {
+	case KeyPath:
+		return false
+	case KeyFold:
+		return strings.EqualFold(key, r.pattern)
+	case KeyGlob:
+		ok, _ := path.Match(r.pattern, strings.ToLower(key))
+		return ok
+	case KeyRegex:
+		return r.regex != nil && r.regex.MatchString(key)
+	default:
+		return key == r.pattern
+	}
+}
+
+// matchesAt reports if the value at path stored
+// under one of keys matches the rule
+func (r KeyRule) matchesAt(path string, keys ...string) bool {
+	if r.match == KeyPath {
+		return r.regex.MatchString(path)
+	}
+
+	for _, key := range keys {
+		if r.Matches(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the rule, e.g. glob:x-*-key
+func (r KeyRule) String() string {
+	return r.match.String() + ":" + r.pattern
+}
+
+func (r KeyRule) maskStrategy() MaskStrategy {
+	if r.strategy == nil {
+		return defaultStrategy
+	}
+	return r.strategy
+}
+
+// KeyMasker is a Masker that masks values stored under keys
+// matching its rules at any depth in maps, slices and structs.
+// Values under a matching key are masked entirely, strings with
+// the rule strategy and other scalars with their zero value.
+// The target is copied, it is never modified.
+//
+// Combine it with the default masker using ChainMasker:
+//
+//	print.PrintMasker = print.ChainMasker(
+//		print.PrintMasker,
+//		print.NewKeyMasker(print.FoldKey("client_secret"), print.GlobKey("x-*-key")),
+//	)
+type KeyMasker struct {
+	rules  []KeyRule
+	except []KeyRule
+}
+
+// NewKeyMasker creates a KeyMasker with rules
+func NewKeyMasker(rules ...KeyRule) *KeyMasker {
+	return &KeyMasker{rules: rules}
+}
+
+// Except leaves the values matching rules untouched,
+// even when a masking rule matches them
+func (m *KeyMasker) Except(rules ...KeyRule) *KeyMasker {
+	m.except = append(m.except, rules...)
+	return m
+}
+
+// Mask returns a masked copy of target
+func (m *KeyMasker) Mask(target any) (any, error) {
+	return m.maskReport(target, nil)
+}
+
+func (m *KeyMasker) maskReport(target any, hit func(path, rule string)) (any, error) {
+	if target == nil {
+		return nil, nil
+	}
+
+	w := maskWalker{rule: m.rule, skip: exceptRules(m.except), hit: hit}
+	out, err := w.child(rootPath, reflect.ValueOf(target))
+	if err != nil {
+		return nil, err
+	}
+	return out.Interface(), nil
+}
+
+func (m *KeyMasker) rule(path string, keys ...string) (KeyRule, bool) {
+	for _, rule := range m.rules {
+		if rule.matchesAt(path, keys...) {
+			return rule, true
+		}
+	}
+	return KeyRule{}, false
+}
+
+// exceptRules returns a walker skip func matching rules
+func exceptRules(rules []KeyRule) func(path string, keys ...string) bool {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	return func(path string, keys ...string) bool {
+		for _, rule := range rules {
+			if rule.matchesAt(path, keys...) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,128 @@
+package print
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestKeyRuleMatches(t *testing.T) {
+	tests := []struct {
+		name string
+		rule KeyRule
+		key  string
+		want bool
+	}{
+		{"exact", ExactKey("client_secret"), "client_secret", true},
+		{"exact case", ExactKey("client_secret"), "Client_Secret", false},
+		{"fold", FoldKey("client_secret"), "CLIENT_SECRET", true},
+		{"fold other", FoldKey("client_secret"), "client_id", false},
+		{"glob", GlobKey("x-*-key"), "X-Api-Key", true},
+		{"glob suffix", GlobKey("*_secret"), "webhook_secret", true},
+		{"glob other", GlobKey("x-*-key"), "x-request-id", false},
+		{"regex", RegexKey(regexp.MustCompile(`(?i)^(pass|pwd)`)), "PassPhrase", true},
+		{"regex other", RegexKey(regexp.MustCompile(`(?i)^(pass|pwd)`)), "username", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Matches(tt.key); got != tt.want {
+				t.Errorf("%s.Matches(%q) = %v, want %v", tt.rule, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+type webhookConfig struct {
+	Name     string            `json:"name"`
+	Secret   string            `json:"client_secret"`
+	PIN      int               `json:"pin"`
+	Headers  map[string]string `json:"headers"`
+	internal string
+}
+
+func TestKeyMasker(t *testing.T) {
+	m := NewKeyMasker(
+		FoldKey("client_secret"),
+		GlobKey("x-*-key"),
+		ExactKey("pin"),
+		ExactKey("card").WithStrategy(MaskPreserveEnds(0, 4)),
+	)
+
+	payload := map[string]any{
+		"event": "push",
+		"data": map[string]any{
+			"Client_Secret": "s3cr3t-value",
+			"pin":           1234,
+			"card":          "4111111111111111",
+			"items": []any{
+				map[string]any{"x-api-key": "abcdef123456", "id": 1},
+			},
+		},
+		"config": &webhookConfig{
+			Name:     "hook",
+			Secret:   "struct-secret",
+			PIN:      42,
+			Headers:  map[string]string{"X-Auth-Key": "header-secret", "Accept": "*/*"},
+			internal: "kept",
+		},
+	}
+
+	masked, err := m.Mask(payload)
+	if err != nil {
+		t.Fatalf("Mask() error = %v", err)
+	}
+
+	got := MaybePrettyJSON(masked)
+	for _, secret := range []string{"s3cr3t-value", "abcdef123456", "struct-secret", "header-secret", "1234", "411111111111"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("Mask() leaked %q:\n%s", secret, got)
+		}
+	}
+
+	for _, want := range []string{
+		`"Client_Secret": "****"`,
+		`"pin": "****"`,
+		`"card": "************1111"`,
+		`"x-api-key": "****"`,
+		`"id": 1`,
+		`"client_secret": "****"`,
+		`"pin": 0`,
+		`"X-Auth-Key": "****"`,
+		`"Accept": "*/*"`,
+		`"name": "hook"`,
+		`"event": "push"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Mask() missing %s:\n%s", want, got)
+		}
+	}
+
+	config := masked.(map[string]any)["config"].(*webhookConfig)
+	if config.internal != "kept" {
+		t.Errorf("Mask() dropped unexported field, got %q", config.internal)
+	}
+
+	original := payload["config"].(*webhookConfig)
+	if original.Secret != "struct-secret" || payload["data"].(map[string]any)["pin"] != 1234 {
+		t.Errorf("Mask() modified the target")
+	}
+}
+
+func TestChainMasker(t *testing.T) {
+	m := ChainMasker(PrintMasker, NewKeyMasker(FoldKey("client_secret")))
+
+	masked, err := m.Mask(map[string]any{
+		"password":      "secret123",
+		"client_secret": "s3cr3t",
+	})
+	if err != nil {
+		t.Fatalf("Mask() error = %v", err)
+	}
+
+	got := MaybeCompactJSON(masked)
+	want := `{"client_secret":"****","password":"****"}`
+	if got != want {
+		t.Errorf("Mask() = %s, want %s", got, want)
+	}
+}
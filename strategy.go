@@ -0,0 +1,60 @@
+package print
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goliatone/go-masker"
+)
+
+const maskChar = "*"
+
+// MaskStrategy replaces a sensitive string with its masked form
+type MaskStrategy interface {
+	MaskString(value string) (string, error)
+}
+
+// MaskFunc adapts a function to the MaskStrategy interface
+type MaskFunc func(value string) (string, error)
+
+// MaskString calls f(value)
+func (f MaskFunc) MaskString(value string) (string, error) {
+	return f(value)
+}
+
+// MaskFilled replaces each character of the value
+// with a mask character, keeping its length
+func MaskFilled() MaskStrategy {
+	return MaskFunc(func(value string) (string, error) {
+		return strings.Repeat(maskChar, utf8.RuneCountInString(value)), nil
+	})
+}
+
+// MaskFixed replaces the value with n mask characters,
+// hiding its length
+func MaskFixed(n int) MaskStrategy {
+	return MaskFunc(func(value string) (string, error) {
+		return strings.Repeat(maskChar, n), nil
+	})
+}
+
+// MaskPreserveEnds keeps the first start and last end
+// characters of the value, e.g. "sk_l********7890"
+func MaskPreserveEnds(start, end int) MaskStrategy {
+	return MaskFunc(func(value string) (string, error) {
+		return masker.MaskPreserveEnds(fmt.Sprintf("(%d,%d)", start, end), value)
+	})
+}
+
+// RegisterMaskStrategy registers s with the default masker under
+// name, so struct tags can select it, e.g. `mask:"fingerprint"`
+func RegisterMaskStrategy(name string, s MaskStrategy) {
+	masker.Default.RegisterMaskStringFunc(name, func(_, value string) (string, error) {
+		return s.MaskString(value)
+	})
+}
+
+// defaultStrategy is used by rules without a strategy,
+// it matches how go-masker renders passwords
+var defaultStrategy = MaskFixed(4)
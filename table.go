@@ -0,0 +1,236 @@
+package print
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// TableStyle controls how a table is drawn
+type TableStyle int
+
+const (
+	// TablePlain renders columns separated by spaces
+	TablePlain TableStyle = iota
+	// TableBox renders borders using box-drawing characters
+	TableBox
+	// TableMarkdown renders a Markdown table
+	TableMarkdown
+)
+
+const (
+	defaultMaxCellWidth = 40
+	minCellWidth        = 3
+	ellipsis            = "…"
+)
+
+var cellReplacer = strings.NewReplacer("\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+type tableConfig struct {
+	style        TableStyle
+	maxCellWidth int
+	width        int
+	columns      []string
+}
+
+// TableOption configures the output of Table and PrintTable
+type TableOption func(*tableConfig)
+
+// WithTableStyle sets the style used to draw the table
+func WithTableStyle(style TableStyle) TableOption {
+	return func(c *tableConfig) {
+		c.style = style
+	}
+}
+
+// WithMaxCellWidth sets the width after which cells
+// are truncated. A value <= 0 disables truncation
+func WithMaxCellWidth(width int) TableOption {
+	return func(c *tableConfig) {
+		c.maxCellWidth = width
+	}
+}
+
+// WithTableWidth sets the total width the table should
+// fit in. By default we use the terminal width. A value
+// <= 0 disables the limit
+func WithTableWidth(width int) TableOption {
+	return func(c *tableConfig) {
+		c.width = width
+	}
+}
+
+// WithColumns selects and orders the columns to render
+func WithColumns(columns ...string) TableOption {
+	return func(c *tableConfig) {
+		c.columns = columns
+	}
+}
+
+// Table renders a slice of structs or maps as an aligned table.
+// Columns are derived from json field names, sensitive values
+// are masked using PrintMasker.
+func Table(data any, opts ...TableOption) (string, error) {
+	cfg := &tableConfig{
+		style:        TablePlain,
+		maxCellWidth: defaultMaxCellWidth,
+		width:        terminalWidth(),
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	columns, rows, err := secureRecordRows(data)
+	if err != nil {
+		return empty, err
+	}
+
+	if len(cfg.columns) > 0 {
+		columns = cfg.columns
+	}
+
+	if len(columns) == 0 {
+		return empty, nil
+	}
+
+	header := make([]string, len(columns))
+	cells := make([][]string, len(rows))
+	for i, column := range columns {
+		header[i] = tableCell(column, cfg.style)
+	}
+
+	for i, row := range rows {
+		cells[i] = make([]string, len(columns))
+		for j, column := range columns {
+			cells[i][j] = tableCell(row[column], cfg.style)
+		}
+	}
+
+	widths := columnWidths(header, cells, cfg)
+
+	return renderTable(header, cells, widths, cfg.style), nil
+}
+
+// PrintTable will render data as a table, in case of
+// an error it will return the message: error printing
+func PrintTable(data any, opts ...TableOption) string {
+	out, err := Table(data, opts...)
+	if err != nil {
+		return fmt.Sprintf("error printing: %s", err)
+	}
+	return out
+}
+
+func tableCell(v any, style TableStyle) string {
+	out := cellReplacer.Replace(cellString(v))
+	if style == TableMarkdown {
+		out = strings.ReplaceAll(out, "|", `\|`)
+	}
+	return out
+}
+
+func columnWidths(header []string, cells [][]string, cfg *tableConfig) []int {
+	widths := make([]int, len(header))
+	for i, h := range header {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+
+	for _, row := range cells {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	if cfg.maxCellWidth > 0 {
+		for i := range widths {
+			widths[i] = min(widths[i], max(cfg.maxCellWidth, minCellWidth))
+		}
+	}
+
+	if cfg.width <= 0 {
+		return widths
+	}
+
+	// shrink the widest column until the table fits
+	overhead := 2 * (len(widths) - 1)
+	if cfg.style != TablePlain {
+		overhead = 3*len(widths) + 1
+	}
+
+	for {
+		total, widest := overhead, 0
+		for i, w := range widths {
+			total += w
+			if w > widths[widest] {
+				widest = i
+			}
+		}
+
+		if total <= cfg.width || widths[widest] <= minCellWidth {
+			return widths
+		}
+		widths[widest]--
+	}
+}
+
+func truncateCell(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+
+	runes := []rune(s)
+	return string(runes[:width-1]) + ellipsis
+}
+
+func padCell(s string, width int) string {
+	s = truncateCell(s, width)
+	return s + strings.Repeat(" ", width-utf8.RuneCountInString(s))
+}
+
+func renderTable(header []string, cells [][]string, widths []int, style TableStyle) string {
+	var b strings.Builder
+
+	line := func(row []string, left, sep, right string) {
+		parts := make([]string, len(row))
+		for i, cell := range row {
+			parts[i] = padCell(cell, widths[i])
+		}
+		out := left + strings.Join(parts, sep) + right
+		b.WriteString(strings.TrimRight(out, " "))
+		b.WriteString("\n")
+	}
+
+	rule := func(left, fill, sep, right string) {
+		parts := make([]string, len(widths))
+		for i, w := range widths {
+			parts[i] = strings.Repeat(fill, w+2)
+		}
+		b.WriteString(left + strings.Join(parts, sep) + right)
+		b.WriteString("\n")
+	}
+
+	switch style {
+	case TableBox:
+		rule("┌", "─", "┬", "┐")
+		line(header, "│ ", " │ ", " │")
+		rule("├", "─", "┼", "┤")
+		for _, row := range cells {
+			line(row, "│ ", " │ ", " │")
+		}
+		rule("└", "─", "┴", "┘")
+	case TableMarkdown:
+		line(header, "| ", " | ", " |")
+		rule("|", "-", "|", "|")
+		for _, row := range cells {
+			line(row, "| ", " | ", " |")
+		}
+	default:
+		line(header, empty, "  ", empty)
+		for _, row := range cells {
+			line(row, empty, "  ", empty)
+		}
+	}
+
+	return b.String()
+}
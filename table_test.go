@@ -0,0 +1,106 @@
+package print
+
+import (
+	"strings"
+	"testing"
+)
+
+type tableUser struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+	Notes    string `json:"notes,omitempty"`
+	internal string
+}
+
+func TestTable(t *testing.T) {
+	users := []tableUser{
+		{ID: 1, Name: "alice", Password: "secret123"},
+		{ID: 2, Name: "bob", Password: "hunter2", Notes: "admin | owner"},
+	}
+
+	tests := []struct {
+		name  string
+		input any
+		opts  []TableOption
+		want  string
+	}{
+		{
+			name:  "plain struct slice",
+			input: users,
+			opts:  []TableOption{WithTableWidth(0)},
+			want: `id  name   password  notes
+1   alice  ****
+2   bob    ****      admin | owner
+`,
+		},
+		{
+			name:  "box style",
+			input: users,
+			opts:  []TableOption{WithTableStyle(TableBox), WithColumns("id", "name")},
+			want: `┌────┬───────┐
+│ id │ name  │
+├────┼───────┤
+│ 1  │ alice │
+│ 2  │ bob   │
+└────┴───────┘
+`,
+		},
+		{
+			name:  "markdown escapes pipes",
+			input: users[1:],
+			opts:  []TableOption{WithTableStyle(TableMarkdown), WithColumns("name", "notes")},
+			want: `| name | notes          |
+|------|----------------|
+| bob  | admin \| owner |
+`,
+		},
+		{
+			name: "maps union keys",
+			input: []map[string]any{
+				{"b": 2, "a": "x"},
+				{"c": []int{1, 2}},
+			},
+			opts: []TableOption{WithTableWidth(0)},
+			want: `a  b  c
+x  2
+      [1,2]
+`,
+		},
+		{
+			name:  "truncate cells",
+			input: []map[string]string{{"label": "abcdefghijklmnop"}},
+			opts:  []TableOption{WithMaxCellWidth(8)},
+			want: `label
+abcdefg…
+`,
+		},
+		{
+			name:  "fit table width",
+			input: []map[string]string{{"a": "aaaaaaaaaa", "b": "bbbbbbbbbb"}},
+			opts:  []TableOption{WithTableWidth(14)},
+			want: `a       b
+aaaaa…  bbbbb…
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Table(tt.input, tt.opts...)
+			if err != nil {
+				t.Fatalf("Table() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Table() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintTableInvalidInput(t *testing.T) {
+	got := PrintTable(map[string]string{"a": "b"})
+	if !strings.HasPrefix(got, "error printing:") {
+		t.Errorf("PrintTable() = %q, want error message", got)
+	}
+}
@@ -0,0 +1,99 @@
+package print
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+const defaultTerminalWidth = 120
+
+// terminalWidth returns the width of the terminal attached to
+// stdout, falling back to the COLUMNS env var and finally to
+// a sensible default when stdout is not a terminal
+func terminalWidth() int {
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		return w
+	}
+
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+
+	return defaultTerminalWidth
+}
+
+// ColorMode controls when output is coloured
+type ColorMode int
+
+const (
+	// ColorAuto colours output when the target is a terminal,
+	// honouring NO_COLOR, FORCE_COLOR and TERM=dumb
+	ColorAuto ColorMode = iota
+	// ColorAlways colours output regardless of the target
+	ColorAlways
+	// ColorNever disables colours
+	ColorNever
+)
+
+// colorEnabled reports if output written to w should be coloured
+func colorEnabled(w io.Writer, mode ColorMode) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	// https://no-color.org
+	if os.Getenv("NO_COLOR") != empty {
+		return false
+	}
+
+	if force := os.Getenv("FORCE_COLOR"); force != empty {
+		if enabled, err := strconv.ParseBool(force); err != nil || enabled {
+			return true
+		}
+		return false
+	}
+
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	return isTerminal(w)
+}
+
+// isTerminal reports if w is a file attached to a terminal
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(interface{ Fd() uintptr })
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// DetectColorDepth infers the colour depth from FORCE_COLOR levels,
+// COLORTERM and TERM, it returns false if there are no hints
+func DetectColorDepth() (ColorDepth, bool) {
+	switch os.Getenv("FORCE_COLOR") {
+	case "2":
+		return Color256, true
+	case "3":
+		return TrueColor, true
+	}
+
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return TrueColor, true
+	}
+
+	switch t := os.Getenv("TERM"); {
+	case strings.Contains(t, "256color"):
+		return Color256, true
+	case t != empty:
+		return Color16, true
+	}
+
+	return 0, false
+}
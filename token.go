@@ -0,0 +1,129 @@
+package print
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// TokenKind identifies the lexical role of a Token
+type TokenKind int
+
+const (
+	TokenWhitespace TokenKind = iota
+	TokenPunctuation
+	TokenKey
+	TokenString
+	TokenNumber
+	TokenBool
+	TokenNull
+	// TokenLineNumber and TokenGuide are added by the layout
+	// options, they are not part of the JSON document
+	TokenLineNumber
+	TokenGuide
+	// TokenComment is added by annotated output
+	TokenComment
+)
+
+// Token is a fragment of pretty printed JSON. Path is the
+// location of the value the token belongs to, e.g. $.user.name
+type Token struct {
+	Kind   TokenKind
+	Value  string
+	Path   string
+	Masked bool
+}
+
+// tokenizer walks a normalized tree and emits tokens
+// that concatenated match the output of PrettyJSON
+type tokenizer struct {
+	tokens []Token
+	masked map[string]bool
+}
+
+// jsonTokens tokenizes a normalized tree, values
+// found in masked are flagged as such
+func jsonTokens(tree any, masked map[string]bool) []Token {
+	t := &tokenizer{masked: masked}
+	t.value(rootPath, tree, 0)
+	t.emit(TokenWhitespace, "\n", rootPath)
+	return t.tokens
+}
+
+func (t *tokenizer) emit(kind TokenKind, value, path string) {
+	t.tokens = append(t.tokens, Token{Kind: kind, Value: value, Path: path})
+}
+
+func (t *tokenizer) indent(path string, depth int) {
+	t.emit(TokenWhitespace, "\n"+strings.Repeat(tab, depth), path)
+}
+
+func (t *tokenizer) value(path string, v any, depth int) {
+	switch value := v.(type) {
+	case map[string]any:
+		if len(value) == 0 {
+			t.emit(TokenPunctuation, "{}", path)
+			return
+		}
+
+		t.emit(TokenPunctuation, "{", path)
+		for i, key := range sortedKeys(value) {
+			if i > 0 {
+				t.emit(TokenPunctuation, ",", path)
+			}
+			childPath := keyPath(path, key)
+			t.indent(path, depth+1)
+			t.emit(TokenKey, marshalScalar(key), childPath)
+			t.emit(TokenPunctuation, ":", childPath)
+			t.emit(TokenWhitespace, " ", childPath)
+			t.value(childPath, value[key], depth+1)
+		}
+		t.indent(path, depth)
+		t.emit(TokenPunctuation, "}", path)
+
+	case []any:
+		if len(value) == 0 {
+			t.emit(TokenPunctuation, "[]", path)
+			return
+		}
+
+		t.emit(TokenPunctuation, "[", path)
+		for i, item := range value {
+			if i > 0 {
+				t.emit(TokenPunctuation, ",", path)
+			}
+			t.indent(path, depth+1)
+			t.value(indexPath(path, i), item, depth+1)
+		}
+		t.indent(path, depth)
+		t.emit(TokenPunctuation, "]", path)
+
+	default:
+		t.tokens = append(t.tokens, Token{
+			Kind:   scalarKind(value),
+			Value:  marshalScalar(value),
+			Path:   path,
+			Masked: t.masked[path],
+		})
+	}
+}
+
+func scalarKind(v any) TokenKind {
+	switch v.(type) {
+	case nil:
+		return TokenNull
+	case bool:
+		return TokenBool
+	case string:
+		return TokenString
+	default:
+		return TokenNumber
+	}
+}
+
+func marshalScalar(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return `"` + unsupportedMessage + `"`
+	}
+	return string(b)
+}
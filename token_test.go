@@ -0,0 +1,89 @@
+package print
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJSONTokensMatchPrettyJSON(t *testing.T) {
+	inputs := []any{
+		nil,
+		"hello <world> & you",
+		42.5,
+		map[string]any{},
+		[]any{},
+		map[string]any{
+			"name":   "john",
+			"x-key":  true,
+			"nested": map[string]any{"list": []any{1, "two", nil, map[string]any{}}},
+			"empty":  []string{},
+		},
+		TestUser{Username: "john", Password: "secret"},
+	}
+
+	for _, input := range inputs {
+		tree, err := jsonTree(input)
+		if err != nil {
+			t.Fatalf("jsonTree() error = %v", err)
+		}
+
+		want := MaybePrettyJSON(input)
+		if got := joinTokens(jsonTokens(tree, nil)); got != want {
+			t.Errorf("jsonTokens() =\n%s\nwant:\n%s", got, want)
+		}
+	}
+}
+
+func TestSecureTokensFlagMaskedValues(t *testing.T) {
+	tokens, err := secureTokens(PrintMasker, map[string]any{
+		"user":     map[string]any{"password": "secret123", "name": "john"},
+		"password": "****",
+	})
+	if err != nil {
+		t.Fatalf("secureTokens() error = %v", err)
+	}
+
+	var masked []string
+	for _, token := range tokens {
+		if token.Masked {
+			masked = append(masked, token.Path)
+		}
+	}
+
+	// $.password was already masked so it did not change
+	if strings.Join(masked, ",") != "$.user.password" {
+		t.Errorf("masked paths = %v, want [$.user.password]", masked)
+	}
+}
+
+func TestSecureHighlightJSONMaskedStyle(t *testing.T) {
+	data := map[string]string{"name": "john", "password": "secret123"}
+
+	p := NewPrinter(
+		WithColorMode(ColorAlways),
+		WithColorDepth(TrueColor),
+		WithMaskGlyph("🔒"),
+	)
+
+	got, err := p.SecureHighlightJSON(data)
+	if err != nil {
+		t.Fatalf("SecureHighlightJSON() error = %v", err)
+	}
+
+	if !strings.Contains(got, PaletteTrueColor.Masked+"🔒 ") {
+		t.Errorf("masked value should use masked style and glyph, got %q", got)
+	}
+
+	if strings.Count(got, "🔒") != 1 {
+		t.Errorf("only masked values should have a glyph, got %q", got)
+	}
+
+	plain, err := NewPrinter(WithColorMode(ColorNever), WithMaskGlyph("🔒")).SecureHighlightJSON(data)
+	if err != nil {
+		t.Fatalf("SecureHighlightJSON() error = %v", err)
+	}
+
+	if want, _ := SecureJSON(data); plain != want {
+		t.Errorf("SecureHighlightJSON() without colour =\n%s\nwant:\n%s", plain, want)
+	}
+}
@@ -0,0 +1,250 @@
+package print
+
+import (
+	"fmt"
+	"strings"
+)
+
+type lineOp struct {
+	kind byte // ' ', '-' or '+'
+	text string
+	a, b int // line numbers in before and after, 1 based
+}
+
+// unifiedDiff returns a unified diff between two texts
+func unifiedDiff(before, after string, context int, color bool) string {
+	ops := diffLines(splitLines(before), splitLines(after))
+
+	var b strings.Builder
+	b.WriteString(colorize("--- a", ansiRed, color) + "\n")
+	b.WriteString(colorize("+++ b", ansiGreen, color) + "\n")
+
+	for _, hunk := range hunks(ops, context) {
+		b.WriteString(colorize(hunkHeader(hunk), ansiCyan, color) + "\n")
+		for _, op := range hunk {
+			line := string(op.kind) + op.text
+			switch op.kind {
+			case '-':
+				line = colorize(line, ansiRed, color)
+			case '+':
+				line = colorize(line, ansiGreen, color)
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// hunks groups changes that are less than 2*context lines
+// apart, each hunk includes context lines around them
+func hunks(ops []lineOp, context int) [][]lineOp {
+	var changes []int
+	for i, op := range ops {
+		if op.kind != ' ' {
+			changes = append(changes, i)
+		}
+	}
+
+	var out [][]lineOp
+	for i := 0; i < len(changes); {
+		first, last := changes[i], changes[i]
+		for i++; i < len(changes) && changes[i]-last <= 2*context; i++ {
+			last = changes[i]
+		}
+		out = append(out, ops[max(first-context, 0):min(last+context+1, len(ops))])
+	}
+	return out
+}
+
+func hunkHeader(hunk []lineOp) string {
+	aStart, bStart := hunk[0].a, hunk[0].b
+	var aLen, bLen int
+	for _, op := range hunk {
+		if op.kind != '+' {
+			aLen++
+		}
+		if op.kind != '-' {
+			bLen++
+		}
+	}
+	// ranges starting with an insertion or a deletion
+	// point at the line before the change
+	if hunk[0].kind == '+' && aLen > 0 {
+		aStart++
+	}
+	if hunk[0].kind == '-' && bLen > 0 {
+		bStart++
+	}
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@", aStart, aLen, bStart, bLen)
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == empty {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// diffLines returns the line edits turning a into b, it uses
+// Myers' algorithm in linear space, splitting the texts at the
+// middle snake of the shortest edit script
+func diffLines(a, b []string) []lineOp {
+	d := &lineDiff{ops: make([]lineOp, 0, len(a)+len(b))}
+	d.diff(a, b)
+	return d.ops
+}
+
+// lineDiff collects edits in order, i and j count
+// the lines of before and after consumed so far
+type lineDiff struct {
+	ops  []lineOp
+	i, j int
+}
+
+func (d *lineDiff) equal(lines []string) {
+	for _, line := range lines {
+		d.i++
+		d.j++
+		d.ops = append(d.ops, lineOp{kind: ' ', text: line, a: d.i, b: d.j})
+	}
+}
+
+func (d *lineDiff) remove(lines []string) {
+	for _, line := range lines {
+		d.i++
+		d.ops = append(d.ops, lineOp{kind: '-', text: line, a: d.i, b: d.j})
+	}
+}
+
+func (d *lineDiff) insert(lines []string) {
+	for _, line := range lines {
+		d.j++
+		d.ops = append(d.ops, lineOp{kind: '+', text: line, a: d.i, b: d.j})
+	}
+}
+
+func (d *lineDiff) diff(a, b []string) {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	d.equal(a[:prefix])
+	middleA, middleB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	switch x, y, ok := middleSnake(middleA, middleB); {
+	case len(middleA) == 0:
+		d.insert(middleB)
+	case len(middleB) == 0:
+		d.remove(middleA)
+	case !ok:
+		d.remove(middleA)
+		d.insert(middleB)
+	default:
+		d.diff(middleA[:x], middleB[:y])
+		d.diff(middleA[x:], middleB[y:])
+	}
+
+	d.equal(a[len(a)-suffix:])
+}
+
+// middleSnake searches forward and backward at the same time,
+// it returns the point where both paths overlap, or false when
+// a and b have no line in common
+func middleSnake(a, b []string) (int, int, bool) {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return 0, 0, false
+	}
+
+	maxD := (n + m + 1) / 2
+	offset, size := maxD, 2*maxD+2
+	forward, backward := make([]int, size), make([]int, size)
+	for i := range forward {
+		forward[i], backward[i] = -1, -1
+	}
+	forward[offset+1], backward[offset+1] = 0, 0
+
+	delta := n - m
+	// with an odd delta the paths overlap on a forward step
+	odd := delta%2 != 0
+	// diagonals that ran past the edges are skipped
+	fStart, fEnd, bStart, bEnd := 0, 0, 0, 0
+
+	for d := 0; d < maxD; d++ {
+		for k := -d + fStart; k <= d-fEnd; k += 2 {
+			i := offset + k
+			var x int
+			if k == -d || (k != d && forward[i-1] < forward[i+1]) {
+				x = forward[i+1]
+			} else {
+				x = forward[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			forward[i] = x
+
+			switch {
+			case x > n:
+				fEnd += 2
+			case y > m:
+				fStart += 2
+			case odd:
+				if j := offset + delta - k; j >= 0 && j < size && backward[j] != -1 && x >= n-backward[j] {
+					return x, y, true
+				}
+			}
+		}
+
+		for k := -d + bStart; k <= d-bEnd; k += 2 {
+			i := offset + k
+			var x int
+			if k == -d || (k != d && backward[i-1] < backward[i+1]) {
+				x = backward[i+1]
+			} else {
+				x = backward[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[n-x-1] == b[m-y-1] {
+				x++
+				y++
+			}
+			backward[i] = x
+
+			switch {
+			case x > n:
+				bEnd += 2
+			case y > m:
+				bStart += 2
+			case !odd:
+				if j := offset + delta - k; j >= 0 && j < size && forward[j] != -1 {
+					fx := forward[j]
+					if fx >= n-x {
+						return fx, offset + fx - j, true
+					}
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// UnifiedDiff returns a unified diff between two texts,
+// it honours WithDiffColor and WithDiffContext
+func UnifiedDiff(before, after string, opts ...DiffOption) string {
+	cfg := newDiffConfig(opts...)
+
+	if before == after {
+		return empty
+	}
+	return unifiedDiff(before, after, cfg.context, cfg.color)
+}
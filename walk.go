@@ -0,0 +1,327 @@
+package print
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// jsonNumberType is masked like other numbers in normalized trees
+var jsonNumberType = reflect.TypeOf(json.Number(""))
+
+// maskWalker copies values masking the values stored under
+// keys matched by rule, the struct fields matched by field and
+// the strings matched by text. field and text return the name
+// of the rule that matched. Values matched by skip are left
+// untouched. hit is called with the path and rule of every
+// masked value
+type maskWalker struct {
+	rule  func(path string, keys ...string) (KeyRule, bool)
+	field func(f reflect.StructField) (string, MaskStrategy, bool)
+	text  func(s string) (string, string, error)
+	skip  func(path string, keys ...string) bool
+	hit   func(path, rule string)
+}
+
+func (w maskWalker) record(path, rule string) {
+	if w.hit != nil && rule != empty {
+		w.hit(path, rule)
+	}
+}
+
+// child copies the value at path stored under keys, values
+// matching a rule are masked entirely
+func (w maskWalker) child(path string, val reflect.Value, keys ...string) (reflect.Value, error) {
+	if w.skip != nil && w.skip(path, keys...) {
+		return val, nil
+	}
+
+	if w.rule != nil {
+		if rule, ok := w.rule(path, keys...); ok {
+			if w.skip != nil && isContainer(val) {
+				// mask every child so exceptions below path still apply
+				inner := w
+				inner.rule = func(string, ...string) (KeyRule, bool) { return rule, true }
+				return inner.walk(path, val)
+			}
+			w.record(path, rule.String())
+			return maskValue(val, rule.maskStrategy())
+		}
+	}
+
+	return w.walk(path, val)
+}
+
+// walk copies val masking the values of matching keys
+func (w maskWalker) walk(path string, val reflect.Value) (reflect.Value, error) {
+	switch val.Kind() {
+	case reflect.Interface:
+		if val.IsNil() {
+			return val, nil
+		}
+		if val.Elem().Type() == jsonNumberType {
+			return w.number(path, val)
+		}
+		elem, err := w.walk(path, val.Elem())
+		if err != nil {
+			return val, err
+		}
+		return wrapValue(val.Type(), elem), nil
+
+	case reflect.Ptr:
+		if val.IsNil() {
+			return val, nil
+		}
+		elem, err := w.walk(path, val.Elem())
+		if err != nil {
+			return val, err
+		}
+		out := reflect.New(val.Type().Elem())
+		out.Elem().Set(elem)
+		return out, nil
+
+	case reflect.Map:
+		if val.IsNil() {
+			return val, nil
+		}
+		out := reflect.MakeMapWithSize(val.Type(), val.Len())
+		for _, key := range val.MapKeys() {
+			name := fmt.Sprintf("%v", key.Interface())
+			item, err := w.child(keyPath(path, name), val.MapIndex(key), name)
+			if err != nil {
+				return val, err
+			}
+			out.SetMapIndex(key, item)
+		}
+		return out, nil
+
+	case reflect.Slice:
+		if val.IsNil() {
+			return val, nil
+		}
+		out := reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+		for i := range val.Len() {
+			item, err := w.child(indexPath(path, i), val.Index(i))
+			if err != nil {
+				return val, err
+			}
+			out.Index(i).Set(item)
+		}
+		return out, nil
+
+	case reflect.Array:
+		out := reflect.New(val.Type()).Elem()
+		for i := range val.Len() {
+			item, err := w.child(indexPath(path, i), val.Index(i))
+			if err != nil {
+				return val, err
+			}
+			out.Index(i).Set(item)
+		}
+		return out, nil
+
+	case reflect.Struct:
+		out := reflect.New(val.Type()).Elem()
+		out.Set(val)
+
+		t := val.Type()
+		for i := range t.NumField() {
+			field := t.Field(i)
+			name, _, ok := jsonFieldName(field)
+			if !ok {
+				continue
+			}
+
+			if w.field != nil {
+				if rule, strategy, ok := w.field(field); ok {
+					item, err := maskValue(val.Field(i), strategy)
+					if err != nil {
+						return val, err
+					}
+					w.record(keyPath(path, name), rule)
+					out.Field(i).Set(item)
+					continue
+				}
+			}
+
+			item, err := w.child(keyPath(path, name), val.Field(i), name, field.Name)
+			if err != nil {
+				return val, err
+			}
+			out.Field(i).Set(item)
+		}
+		return out, nil
+
+	case reflect.String:
+		if w.text == nil || val.Type() == jsonNumberType {
+			// numbers held by interfaces are scanned by number
+			return val, nil
+		}
+		masked, rule, err := w.text(val.String())
+		if err != nil {
+			return val, fmt.Errorf("error masking value: %w", err)
+		}
+		w.record(path, rule)
+		out := reflect.New(val.Type()).Elem()
+		out.SetString(masked)
+		return out, nil
+
+	default:
+		return val, nil
+	}
+}
+
+// number scans the json.Number held by val as text. A masked
+// number is no longer valid JSON, so it is stored as a string
+func (w maskWalker) number(path string, val reflect.Value) (reflect.Value, error) {
+	if w.text == nil {
+		return val, nil
+	}
+
+	n := val.Elem().String()
+	masked, err := w.walk(path, reflect.ValueOf(n))
+	if err != nil {
+		return val, err
+	}
+	if masked.String() == n {
+		return val, nil
+	}
+	if !masked.Type().AssignableTo(val.Type()) {
+		return reflect.Zero(val.Type()), nil
+	}
+	return wrapValue(val.Type(), masked), nil
+}
+
+// maskValue returns a copy of val with every string masked using
+// strategy and other scalars replaced with their zero value
+func maskValue(val reflect.Value, strategy MaskStrategy) (reflect.Value, error) {
+	switch val.Kind() {
+	case reflect.String:
+		if val.Type() == jsonNumberType {
+			// a masked json.Number would not encode
+			return reflect.Zero(val.Type()), nil
+		}
+		masked, err := strategy.MaskString(val.String())
+		if err != nil {
+			return val, fmt.Errorf("error masking value: %w", err)
+		}
+		out := reflect.New(val.Type()).Elem()
+		out.SetString(masked)
+		return out, nil
+
+	case reflect.Interface:
+		if val.IsNil() {
+			return val, nil
+		}
+		elem := val.Elem()
+		if isScalar(elem.Kind()) && (elem.Kind() != reflect.String || elem.Type() == jsonNumberType) {
+			// keep the shape of dynamic values, 1234 prints as "****"
+			elem = reflect.ValueOf(fmt.Sprintf("%v", elem.Interface()))
+		}
+		masked, err := maskValue(elem, strategy)
+		if err != nil {
+			return val, err
+		}
+		if !masked.Type().AssignableTo(val.Type()) {
+			return reflect.Zero(val.Type()), nil
+		}
+		return wrapValue(val.Type(), masked), nil
+
+	case reflect.Ptr:
+		if val.IsNil() {
+			return val, nil
+		}
+		elem, err := maskValue(val.Elem(), strategy)
+		if err != nil {
+			return val, err
+		}
+		out := reflect.New(val.Type().Elem())
+		out.Elem().Set(elem)
+		return out, nil
+
+	case reflect.Map:
+		if val.IsNil() {
+			return val, nil
+		}
+		out := reflect.MakeMapWithSize(val.Type(), val.Len())
+		for _, key := range val.MapKeys() {
+			item, err := maskValue(val.MapIndex(key), strategy)
+			if err != nil {
+				return val, err
+			}
+			out.SetMapIndex(key, item)
+		}
+		return out, nil
+
+	case reflect.Slice, reflect.Array:
+		if val.Kind() == reflect.Slice && val.IsNil() {
+			return val, nil
+		}
+		out := reflect.New(val.Type()).Elem()
+		if val.Kind() == reflect.Slice {
+			out = reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+		}
+		for i := range val.Len() {
+			item, err := maskValue(val.Index(i), strategy)
+			if err != nil {
+				return val, err
+			}
+			out.Index(i).Set(item)
+		}
+		return out, nil
+
+	case reflect.Struct:
+		out := reflect.New(val.Type()).Elem()
+		t := val.Type()
+		for i := range t.NumField() {
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			item, err := maskValue(val.Field(i), strategy)
+			if err != nil {
+				return val, err
+			}
+			out.Field(i).Set(item)
+		}
+		return out, nil
+
+	default:
+		return reflect.Zero(val.Type()), nil
+	}
+}
+
+// wrapValue stores val in a new value of the interface type t
+func wrapValue(t reflect.Type, val reflect.Value) reflect.Value {
+	out := reflect.New(t).Elem()
+	out.Set(val)
+	return out
+}
+
+// isContainer reports if val holds a map, slice,
+// array or struct with exported fields
+func isContainer(val reflect.Value) bool {
+	for val.Kind() == reflect.Interface || val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return false
+		}
+		val = val.Elem()
+	}
+
+	switch val.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return true
+	case reflect.Struct:
+		return exportedFields(val.Type()) > 0
+	}
+	return false
+}
+
+func isScalar(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}